@@ -1,117 +1,249 @@
 package main
 
 import (
-  "io"
-  "os"
-  "fmt"
-  "math"
+  "anscombe/stats"
   "flag"
-  "slices"
+  "fmt"
+  "os"
 )
 
-const errmsg string =
-`Incorrect input:
-expected a non-empty sequence of integers,
-strictly between -100000 and 100000 , separated by newlines`
+const limit float64 = 100000
 
-func readNums() []int {
-  var nums []int
+func main() {
+  os.Exit(run())
+}
 
-  for {
-    var num int
-    _, err := fmt.Scanln(&num)
-    if err == io.EOF {
-      break
-    }
-    if err != nil || math.Abs(float64(num)) > 100000.0 {
-      return nil
-    }
-    nums = append(nums, num)
+// run runs the program and returns its exit status.
+func run() int {
+  streamFlag := flag.Bool("stream", false,
+    "A bool. Read the input in constant memory, estimating the quantiles\n"+
+      "(mode and MAD are not available)")
+  epsilonFlag := flag.Float64("epsilon", 0.001,
+    "A float. Rank error of the quantile estimates in stream mode")
+  modesFlag := flag.Bool("modes", false,
+    "A bool. Print all modes with their counts")
+  freqFlag := flag.Bool("freq", false,
+    "A bool. Print the frequency table")
+  minFlag := flag.Float64("min", -limit,
+    "A float. The smallest accepted input value")
+  maxFlag := flag.Float64("max", limit,
+    "A float. The largest accepted input value")
+  unboundedFlag := flag.Bool("unbounded", false,
+    "A bool. Accept any finite input value, ignoring -min and -max")
+  skipFlag := flag.Bool("skip-invalid", false,
+    "A bool. Ignore invalid lines and print a summary of them to stderr")
+  pairsFlag := flag.Bool("pairs", false,
+    "A bool. Read x,y pairs and describe the relation between them")
+  flag.Parse()
+
+  if ddofFlag < 0 {
+    fmt.Fprintln(os.Stderr, "-ddof must not be negative")
+    return exitUsage
+  }
+  if binsFlag < 0 || binsFlag > maxBins || binWidthFlag < 0 || widthFlag < 10 {
+    fmt.Fprintf(os.Stderr, "-bins must be between 0 and %d, -bin-width"+
+      " must not be negative and -width must be at least 10\n", maxBins)
+    return exitUsage
+  }
+  if !(cutFlag >= 0 && cutFlag < 0.5) {
+    fmt.Fprintln(os.Stderr, "-cut must be at least 0 and less than 0.5")
+    return exitUsage
+  }
+  if ciFlag != 0 && (!(ciFlag > 0 && ciFlag < 100) || resamplesFlag < 1) {
+    fmt.Fprintln(os.Stderr, "-ci must be between 0 and 100"+
+      " and -resamples positive")
+    return exitUsage
+  }
+  if *epsilonFlag <= 0 || *epsilonFlag >= 1 {
+    fmt.Fprintln(os.Stderr, "-epsilon must be strictly between 0 and 1")
+    return exitUsage
+  }
+  if flag.NArg() != 0 && flag.NArg() != 2 {
+    fmt.Fprintln(os.Stderr, "Two files of samples to compare are expected,"+
+      " or none to read stdin")
+    return exitUsage
+  }
+  if precisionFlag < -1 {
+    fmt.Fprintln(os.Stderr, "-precision must be -1 or more")
+    return exitUsage
+  }
+  if (saveBaselineFlag != "" || compareFlag != "") && (groupFlag != 0 ||
+    windowSize != 0 || windowSpan != 0 || inputFlag != "lines" || serveFlag != "") {
+    fmt.Fprintln(os.Stderr, "-save-baseline and -compare cannot be combined"+
+      " with -group, -window, -input csv or tsv, or -serve")
+    return exitUsage
+  }
+  // The chart is drawn by the default mode and -pairs only, and the
+  // modes before -pairs take precedence over it.
+  if plotFlag != "" && (groupFlag != 0 || aFlag != "" || bFlag != "" ||
+    flag.NArg() != 0 || windowSize != 0 || windowSpan != 0 ||
+    weightsFlag != "" || serveFlag != "" || !*pairsFlag && (*streamFlag ||
+    exactFlag || inputFlag != "lines" || categoricalFlag || timeseriesFlag ||
+    normalityFlag || parallelFlag)) {
+    fmt.Fprintln(os.Stderr, "-plot is only available in the default mode"+
+      " and with -pairs")
+    return exitUsage
   }
 
-  return nums
-}
+  // The exact mode comes after the others in the dispatch, so it is
+  // checked first rather than losing to them.
+  if exactFlag && (groupFlag != 0 || aFlag != "" || bFlag != "" ||
+    flag.NArg() != 0 || windowSize != 0 || windowSpan != 0 ||
+    weightsFlag != "" || *pairsFlag || *streamFlag || serveFlag != "" ||
+    inputFlag != "lines" || categoricalFlag || timeseriesFlag ||
+    normalityFlag || parallelFlag) {
+    fmt.Fprintln(os.Stderr, "-exact cannot be combined with the other"+
+      " modes: -group, two samples, -window, -weights, -pairs, -stream,"+
+      " -serve, -input csv or tsv, -categorical, -timeseries, -normality"+
+      " or -parallel")
+    return exitUsage
+  }
+  if exactFlag && (ciFlag != 0 || histFlag || boxFlag || outliersFlag != "" ||
+    trimFlag || *modesFlag || *freqFlag || plotFlag != "") {
+    fmt.Fprintln(os.Stderr, "-exact cannot be combined with -ci, -hist, -box,"+
+      " -outliers, -trim, -modes, -freq or -plot")
+    return exitUsage
+  }
 
-func average(nums []int) float64 {
-  var avg float64
-  var n float64 = float64(len(nums))
-  for _, num := range nums {
-    avg += float64(num) / n
+  rg := stats.Range{Min: *minFlag, Max: *maxFlag}
+  if *unboundedFlag {
+    rg = stats.Unbounded
+  } else if !(rg.Min <= rg.Max) {
+    fmt.Fprintln(os.Stderr, "-min must not exceed -max")
+    return exitUsage
+  }
+
+  if serveFlag != "" {
+    return serve(rg, serveFlag, *epsilonFlag)
   }
-  return avg
-}
 
-func median(nums []int) float64 {
-  var med float64
-  idx := len(nums) / 2
+  reader, skipped := newReader(rg, *skipFlag)
+  defer skipped.print(os.Stderr)
 
-  if len(nums) % 2 != 0 {
-    med = float64(nums[idx])
-  } else {
-    med = float64(nums[idx - 1] + nums[idx]) / 2.0
+  if groupFlag != 0 {
+    if groupFlag != 1 && groupFlag != 2 {
+      fmt.Fprintln(os.Stderr, "-group must be 1 or 2")
+      return exitUsage
+    }
+    return groups(reader)
   }
 
-  return med
-}
+  if aFlag != "" || bFlag != "" || flag.NArg() == 2 {
+    if aFlag == "" && bFlag == "" {
+      aFlag, bFlag = flag.Arg(0), flag.Arg(1)
+    }
+    if aFlag == "" || bFlag == "" || aFlag == "-" && bFlag == "-" {
+      fmt.Fprintln(os.Stderr, "Two samples are expected: -a and -b,"+
+        " at most one of them from stdin")
+      return exitUsage
+    }
+    return compare(reader, aFlag, bFlag)
+  }
 
-func mode(nums []int) int {
-  m := make(map[int]int)
-  for _, num := range nums {
-    m[num] = m[num] + 1
+  if windowSize != 0 || windowSpan != 0 {
+    if everyFlag < 1 || ewmaFlag < 0 || ewmaFlag > 1 {
+      fmt.Fprintln(os.Stderr,
+        "-every must be positive and -ewma between 0 and 1")
+      return exitUsage
+    }
+    return window(reader)
   }
 
-  num := 0
-  count := 0
-  for k, v := range m {
-    if v > count {
-      count = v
-      num = k
-    }  
+  if weightsFlag != "" {
+    if *pairsFlag || *streamFlag || *modesFlag || *freqFlag || histFlag ||
+      boxFlag || outliersFlag != "" || ciFlag != 0 {
+      fmt.Fprintln(os.Stderr, "-weights cannot be combined with -pairs,"+
+        " -stream, -modes, -freq, -hist, -box, -outliers or -ci")
+      return exitUsage
+    }
+    return weighted(reader)
   }
 
-  return num
-}
+  if *pairsFlag {
+    return pairs(reader, enabled("mean"), enabled("deviation"))
+  }
 
-func standardDeviation(nums []int) float64 {
-  avg := average(nums)
+  if *streamFlag {
+    return stream(reader, *epsilonFlag)
+  }
 
-  var variance float64
-  for _, num := range nums {
-    tmp := float64(num) - avg
-    variance += tmp * tmp 
+  if exactFlag {
+    return exact(reader)
   }
 
-  return math.Sqrt(variance / float64(len(nums)))
-}
+  if inputFlag != "lines" {
+    return columns(reader)
+  }
 
-func main() {
-  meanFlag := flag.Bool("mean", true,
-                        "A bool. Unable/disable calculation of mean")
-  medianFlag := flag.Bool("median", true,
-                        "A bool. Unable/disable calculation of median")
-  modeFlag := flag.Bool("mode", true,
-                        "A bool. Unable/disable calculation of mode")
-  deviationFlag := flag.Bool("deviation", true,
-                        "A bool. Unable/disable calculation of deviation")
-  flag.Parse()
+  if categoricalFlag {
+    if topFlag < 0 {
+      fmt.Fprintln(os.Stderr, "-top must not be negative")
+      return exitUsage
+    }
+    return categorical(reader)
+  }
 
-  nums := readNums()
-  if nums == nil {
-    fmt.Fprintln(os.Stderr, errmsg)
-    return
+  if timeseriesFlag {
+    if maFlag < 0 || !(alphaFlag > 0 && alphaFlag < 1) || minSegmentFlag < 1 {
+      fmt.Fprintln(os.Stderr, "-ma must not be negative, -alpha must be"+
+        " between 0 and 1 and -min-segment positive")
+      return exitUsage
+    }
+    return timeseries(reader)
   }
-  slices.Sort(nums)
 
-  if *meanFlag {
-    fmt.Printf("Mean: %.2f\n", average(nums))
+  if normalityFlag {
+    return normality(reader)
   }
-  if *medianFlag {
-    fmt.Printf("Median: %.2f\n", median(nums))
+
+  if parallelFlag {
+    if jobsFlag < 0 {
+      fmt.Fprintln(os.Stderr, "-jobs must not be negative")
+      return exitUsage
+    }
+    return parallel(reader)
+  }
+
+  nums, lines, err := reader.ReadNumbered(os.Stdin)
+  if err == nil && len(nums) == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintln(os.Stderr, errmsg(err, rg))
+    return exitInvalidInput
+  }
+  var r report
+  nums = r.findOutliers(nums, lines)
+  sample := stats.NewSample(nums)
+
+  first, sel := len(r.fields), selected()
+  for _, m := range sel {
+    r.fields = append(r.fields, m.field(m.value(sample)))
   }
-  if *modeFlag { 
-    fmt.Printf("Mode: %d\n", mode(nums))
+  if ciFlag != 0 {
+    r.addIntervals(nums, sel, first)
   }
-  if *deviationFlag {
-    fmt.Printf("SD: %.2f\n", standardDeviation(nums))
+  if *modesFlag {
+    r.addFrequencies("modes", "Modes", sample.Modes())
+  }
+  if *freqFlag {
+    r.addFrequencies("frequencies", "Frequencies", sample.Frequencies())
+  }
+  if histFlag {
+    bins, err := histogram(sample)
+    if err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return exitUsage
+    }
+    r.addHistogram(bins)
+  }
+  if boxFlag {
+    r.addBoxPlot(sample)
+  }
+  if plotFlag != "" {
+    if status := plotSample(sample); status != exitOK {
+      return status
+    }
   }
+  return r.print()
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+  "encoding/json"
+  "errors"
+  "flag"
+  "fmt"
+  "math"
+  "os"
+  "strconv"
+  "strings"
+)
+
+var (
+  saveBaselineFlag string
+  compareFlag      string
+  toleranceFlag    float64 = 5
+)
+
+func init() {
+  flag.StringVar(&saveBaselineFlag, "save-baseline", "",
+    "A string. Save the computed metrics to the JSON file")
+  flag.StringVar(&compareFlag, "compare", "",
+    "A string. Compare the computed metrics to the baseline saved in\n"+
+      "the JSON file and exit with status 4 if any moved beyond -tolerance")
+  flag.Func("tolerance",
+    "A percentage. Largest accepted relative change of a metric from\n"+
+      "the baseline, e.g. 5% (default 5%)",
+    func(s string) error {
+      x, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+      if err != nil || !(x >= 0) {
+        return errors.New("expected a non-negative percentage")
+      }
+      toleranceFlag = x
+      return nil
+    })
+}
+
+// saveBaseline writes the fields of the report to the file as a JSON
+// object, with the shortest exact representation of the values.
+func (r *report) saveBaseline(name string) error {
+  if len(r.fields) == 0 {
+    return errors.New("-save-baseline: the report has no metrics")
+  }
+  var members []string
+  for _, f := range r.fields {
+    if f.setting {
+      continue
+    }
+    value := "null"
+    if !math.IsNaN(f.value) && !math.IsInf(f.value, 0) {
+      value = strconv.FormatFloat(f.value, 'g', -1, 64)
+    }
+    members = append(members, jsonString(f.key)+": "+value)
+  }
+  data := fmt.Sprintf("{\n  %s\n}\n", strings.Join(members, ",\n  "))
+  return os.WriteFile(name, []byte(data), 0o644)
+}
+
+// slack is the relative error accepted in the comparison of a change
+// with the tolerance, so that a change of exactly the tolerance passes
+// despite the rounding of the percentage.
+const slack = 1e-9
+
+// compareBaseline adds to the report the table of changes of the fields
+// from the baseline in the file, and reports whether any of them moved
+// beyond the tolerance. Fields missing from the baseline are ignored.
+func (r *report) compareBaseline(name string) (regressed bool, err error) {
+  data, err := os.ReadFile(name)
+  if err != nil {
+    return false, err
+  }
+  // Values other than numbers and null, like the tables of a report
+  // written with -format json, are ignored.
+  var baseline map[string]any
+  if err := json.Unmarshal(data, &baseline); err != nil {
+    return false, fmt.Errorf("%s: %v", name, err)
+  }
+
+  t := table{key: "baseline", title: "Changes from the baseline",
+    label: "metric", columns: []column{
+      {"baseline", false}, {"current", false}, {"change_pct", false},
+    }}
+  for _, f := range r.fields {
+    if f.setting {
+      continue
+    }
+    base, ok := baseline[f.key]
+    old, number := base.(float64)
+    if !ok || !number && base != nil {
+      continue
+    }
+    if base == nil {
+      old = math.NaN()
+    }
+
+    change := (f.value - old) / math.Abs(old) * 100
+    if f.value == old || math.IsNaN(f.value) && math.IsNaN(old) {
+      change = 0
+    }
+    t.labels = append(t.labels, f.key)
+    t.rows = append(t.rows, []float64{old, f.value, change})
+    if !(math.Abs(change) <= toleranceFlag*(1+slack)) {
+      regressed = true
+      fmt.Fprintf(os.Stderr,
+        "Regression: %s moved by %.2f%%, from %s to %s\n", f.key, change,
+        formatNumber(old, f.exact), formatNumber(f.value, f.exact))
+    }
+  }
+  if len(t.rows) == 0 {
+    return false, fmt.Errorf("%s: no metric of the baseline was computed", name)
+  }
+  r.tables = append(r.tables, t)
+  return regressed, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+  "encoding/json"
+  "math"
+  "os"
+  "path/filepath"
+  "testing"
+)
+
+// quiet discards the standard output and error until the returned
+// function is called.
+func quiet(t *testing.T) func() {
+  t.Helper()
+  null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  stdout, stderr := os.Stdout, os.Stderr
+  os.Stdout, os.Stderr = null, null
+  return func() {
+    os.Stdout, os.Stderr = stdout, stderr
+    null.Close()
+  }
+}
+
+func TestSaveBaseline(t *testing.T) {
+  name := filepath.Join(t.TempDir(), "baseline.json")
+  r := report{fields: []field{
+    {key: "mean", value: 0.1},
+    {key: "count", value: 3, exact: true},
+    {key: "skewness", value: math.NaN()},
+    {key: "seed", value: 7, setting: true},
+  }}
+  if err := r.saveBaseline(name); err != nil {
+    t.Fatal(err)
+  }
+
+  data, err := os.ReadFile(name)
+  if err != nil {
+    t.Fatal(err)
+  }
+  var got map[string]any
+  if err := json.Unmarshal(data, &got); err != nil {
+    t.Fatalf("%v in %s", err, data)
+  }
+  if got["mean"] != 0.1 || got["count"] != 3.0 || got["skewness"] != nil || len(got) != 3 {
+    t.Errorf("got %v", got)
+  }
+
+  if err := (&report{}).saveBaseline(name); err == nil {
+    t.Error("saved a report without metrics")
+  }
+}
+
+func TestCompareBaseline(t *testing.T) {
+  name := filepath.Join(t.TempDir(), "baseline.json")
+  base := report{fields: []field{{key: "mean", value: 2}, {key: "sd", value: math.NaN()}}}
+  if err := base.saveBaseline(name); err != nil {
+    t.Fatal(err)
+  }
+
+  saved := toleranceFlag
+  defer func() { toleranceFlag = saved }()
+  tests := []struct {
+    name      string
+    mean, sd  float64
+    tolerance float64
+    regressed bool
+  }{
+    {"equal", 2, math.NaN(), 5, false},
+    {"within", 2.05, math.NaN(), 5, false},
+    {"exactly the tolerance", 2.1, math.NaN(), 5, false},
+    {"decrease by the tolerance", 1.9, math.NaN(), 5, false},
+    {"beyond", 2.1001, math.NaN(), 5, true},
+    {"zero tolerance", 2.1, math.NaN(), 0, true},
+    {"NaN to number", 2, 1, 5, true},
+  }
+  for _, tt := range tests {
+    toleranceFlag = tt.tolerance
+    r := report{fields: []field{{key: "mean", value: tt.mean},
+      {key: "sd", value: tt.sd}, {key: "new", value: 1},
+      {key: "seed", value: 1, setting: true}}}
+    restore := quiet(t)
+    regressed, err := r.compareBaseline(name)
+    restore()
+    if err != nil || regressed != tt.regressed {
+      t.Errorf("%s: got %v, %v, want %v", tt.name, regressed, err, tt.regressed)
+    }
+    if len(r.tables) != 1 || len(r.tables[0].rows) != 2 {
+      t.Errorf("%s: got tables %v, want a row per metric of the baseline", tt.name, r.tables)
+    }
+  }
+
+  r := report{fields: []field{{key: "other", value: 1}}}
+  if _, err := r.compareBaseline(name); err == nil {
+    t.Error("compared a report without metrics of the baseline")
+  }
+  if _, err := r.compareBaseline(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+    t.Error("compared to a missing baseline")
+  }
+}
+
+func TestPrintBaseline(t *testing.T) {
+  dir := t.TempDir()
+  name := filepath.Join(dir, "baseline.json")
+  savedCompare, savedSave := compareFlag, saveBaselineFlag
+  defer func() { compareFlag, saveBaselineFlag = savedCompare, savedSave }()
+
+  steps := []struct {
+    save, compare string
+    mean          float64
+    want          int
+  }{
+    {name, "", 2, exitOK},
+    {"", name, 2, exitOK},
+    {"", name, 3, exitRegression},
+    {"", filepath.Join(dir, "missing.json"), 2, exitFailure},
+    {filepath.Join(dir, "missing", "baseline.json"), "", 2, exitFailure},
+  }
+  for _, step := range steps {
+    saveBaselineFlag, compareFlag = step.save, step.compare
+    r := report{fields: []field{{key: "mean", title: "Mean", value: step.mean}}}
+    restore := quiet(t)
+    got := r.print()
+    restore()
+    if got != step.want {
+      t.Errorf("save %q, compare %q, mean %v: exit status %d, want %d",
+        step.save, step.compare, step.mean, got, step.want)
+    }
+  }
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "strconv"
+  "time"
+)
+
+var (
+  ciFlag        float64
+  resamplesFlag int   = 2000
+  seedFlag      int64 = time.Now().UnixNano()
+  bcaFlag       bool
+)
+
+func init() {
+  flag.Float64Var(&ciFlag, "ci", 0,
+    "A float. Confidence level in percent of bootstrap intervals\n"+
+      "printed with every metric, e.g. 95")
+  flag.IntVar(&resamplesFlag, "resamples", 2000,
+    "An int. Number of bootstrap resamples")
+  flag.Func("seed", "An int. Seed of the bootstrap resampling (default random)",
+    func(s string) (err error) {
+      seedFlag, err = strconv.ParseInt(s, 10, 64)
+      return err
+    })
+  flag.BoolVar(&bcaFlag, "bca", false,
+    "A bool. Use bias-corrected and accelerated bootstrap intervals\n"+
+      "instead of the percentile ones")
+}
+
+// addIntervals sets bootstrap confidence intervals of the metrics of
+// nums to their fields in the report, which start at the index first.
+func (r *report) addIntervals(nums []float64, sel []*metric, first int) {
+  statistics := make([]stats.Statistic, len(sel))
+  for i, m := range sel {
+    statistics[i] = m.value
+  }
+  b := stats.Bootstrap{Resamples: resamplesFlag, Seed: seedFlag}
+  reps := b.Replicates(nums, statistics...)
+
+  level := ciFlag / 100
+  for i, m := range sel {
+    f := &r.fields[first+i]
+    f.estimate = true
+    if bcaFlag {
+      f.lo, f.hi = stats.BCaInterval(nums, m.value, reps[i], level)
+    } else {
+      f.lo, f.hi = stats.PercentileInterval(reps[i], level)
+    }
+  }
+
+  // The seed is reported, as it is random unless set, so that the run
+  // can be repeated with -seed.
+  r.fields = append(r.fields, field{key: "ci_level",
+    title: "Confidence level, %", value: ciFlag, exact: true, setting: true},
+    field{key: "seed", title: "Seed", value: float64(seedFlag),
+      text: strconv.FormatInt(seedFlag, 10), setting: true})
+}
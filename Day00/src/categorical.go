@@ -0,0 +1,127 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "flag"
+  "fmt"
+  "os"
+  "strconv"
+  "strings"
+)
+
+// distribution is the value of -expected: relative frequencies of
+// categories.
+type distribution struct {
+  names   []string
+  weights []float64
+}
+
+func (d *distribution) String() string {
+  var pairs []string
+  for i, name := range d.names {
+    pairs = append(pairs, name+"="+strconv.FormatFloat(d.weights[i], 'g', -1, 64))
+  }
+  return strings.Join(pairs, ",")
+}
+
+func (d *distribution) Set(value string) error {
+  for _, pair := range strings.Split(value, ",") {
+    name, weight, ok := strings.Cut(pair, "=")
+    w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+    if !ok || err != nil || !(w >= 0) {
+      return errors.New("expected category=weight pairs," +
+        " e.g. red=0.5,green=0.3,blue=0.2")
+    }
+    d.names = append(d.names, strings.TrimSpace(name))
+    d.weights = append(d.weights, w)
+  }
+  return nil
+}
+
+var (
+  categoricalFlag bool
+  topFlag         int = 10
+  expectedFlag    distribution
+)
+
+func init() {
+  flag.BoolVar(&categoricalFlag, "categorical", false,
+    "A bool. Read a token per line and describe their frequencies")
+  flag.IntVar(&topFlag, "top", 10,
+    "An int. Number of the most frequent categories printed, 0 for all")
+  flag.Var(&expectedFlag, "expected",
+    "A list. Expected relative frequencies of the categories for the\n"+
+      "chi-squared test, e.g. red=0.5,green=0.3,blue=0.2 (default uniform)")
+}
+
+// categorical describes tokens: the number of distinct ones, the most
+// frequent ones, the entropy and the fit to the expected distribution.
+func categorical(reader *stats.Reader) int {
+  tokens, err := reader.ReadTokens(os.Stdin)
+  if err == nil && len(tokens) == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected a non-empty sequence of tokens, separated by newlines\n",
+      err)
+    return exitInvalidInput
+  }
+
+  categories := stats.Categories(tokens)
+  counts := make([]int, len(categories))
+  for i, c := range categories {
+    counts[i] = c.Count
+  }
+
+  // The expected distribution covers the observed categories and may
+  // add ones that were not observed.
+  var expected []float64
+  if expectedFlag.names != nil {
+    weights := make(map[string]float64)
+    for i, name := range expectedFlag.names {
+      weights[name] = expectedFlag.weights[i]
+    }
+    for _, c := range categories {
+      w, ok := weights[c.Value]
+      if !ok {
+        fmt.Fprintf(os.Stderr, "-expected: no frequency of %q\n", c.Value)
+        return exitUsage
+      }
+      expected = append(expected, w)
+      delete(weights, c.Value)
+    }
+    for _, name := range expectedFlag.names {
+      if w, ok := weights[name]; ok {
+        counts = append(counts, 0)
+        expected = append(expected, w)
+      }
+    }
+  }
+
+  var r report
+  r.fields = append(r.fields,
+    field{key: "count", title: "Count", value: float64(len(tokens)), exact: true},
+    field{key: "distinct", title: "Distinct", value: float64(len(categories)),
+      exact: true})
+  r.add("entropy", "Entropy, bits", stats.Entropy(counts))
+  chi2 := stats.ChiSquare(counts, expected)
+  r.addTest("chi_square", "Chi-squared", chi2.Statistic)
+  r.fields = append(r.fields,
+    field{key: "chi_square_df", title: "Chi-squared df", value: chi2.DF,
+      exact: true})
+  r.addTest("chi_square_p", "Chi-squared p-value", chi2.P)
+
+  t := table{key: "top", title: "Most frequent", label: "value",
+    columns: []column{{"count", true}, {"relative", false}}}
+  if topFlag > 0 && topFlag < len(categories) {
+    categories = categories[:topFlag]
+  }
+  for _, c := range categories {
+    t.labels = append(t.labels, c.Value)
+    t.rows = append(t.rows, []float64{float64(c.Count), c.Relative})
+  }
+  r.tables = append(r.tables, t)
+  return r.print()
+}
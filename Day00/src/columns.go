@@ -0,0 +1,232 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/csv"
+  "errors"
+  "flag"
+  "fmt"
+  "io"
+  "math"
+  "os"
+  "strconv"
+  "strings"
+)
+
+// describeMetrics are the metrics of every column of tabular input.
+var describeMetrics = []string{
+  "count", "mean", "deviation", "minimum", "q1", "median", "q3", "maximum",
+}
+
+var (
+  inputFlag   string = "lines"
+  columnsFlag []string
+)
+
+func init() {
+  flag.Func("input",
+    "A string. Input format: lines of numbers, or csv or tsv with\n"+
+      "a header, described per column with a correlation matrix\n"+
+      "(default lines)",
+    func(s string) error {
+      switch s {
+      case "lines", "csv", "tsv":
+        inputFlag = s
+        return nil
+      }
+      return errors.New("expected lines, csv or tsv")
+    })
+  flag.Func("columns",
+    "A list. Comma separated names or 1-based indices of the columns\n"+
+      "of csv or tsv input (default every numeric column)",
+    func(s string) error {
+      for _, c := range strings.Split(s, ",") {
+        columnsFlag = append(columnsFlag, strings.TrimSpace(c))
+      }
+      return nil
+    })
+}
+
+// dataColumn is a column of tabular input. Missing values are NaN.
+type dataColumn struct {
+  name   string
+  index  int
+  values []float64
+  // numeric is false if a value of the column is not a number.
+  numeric bool
+}
+
+// columns describes every selected numeric column of tabular input and
+// the correlations between them.
+func columns(reader *stats.Reader) int {
+  cr := csv.NewReader(os.Stdin)
+  cr.TrimLeadingSpace = true
+  if inputFlag == "tsv" {
+    cr.Comma = '\t'
+  }
+
+  header, err := cr.Read()
+  if err == io.EOF {
+    err = errors.New("no header")
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n", err)
+    return exitInvalidInput
+  }
+  cols, err := selectColumns(header, columnsFlag)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "-columns: %v\n", err)
+    return exitUsage
+  }
+  if err := readColumns(cr, cols, reader, columnsFlag != nil); err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n", err)
+    return exitInvalidInput
+  }
+
+  var numeric []*dataColumn
+  for _, c := range cols {
+    if c.numeric {
+      numeric = append(numeric, c)
+    } else {
+      fmt.Fprintf(os.Stderr, "Skipping the non-numeric column %s\n", c.name)
+    }
+  }
+  if len(numeric) == 0 {
+    fmt.Fprintln(os.Stderr, "Incorrect input: no numeric columns")
+    return exitInvalidInput
+  }
+
+  r := describeColumns(numeric)
+  return r.print()
+}
+
+// selectColumns returns the columns of the header selected by names or
+// 1-based indices, or all of them if selection is nil. A name matches
+// before an index.
+func selectColumns(header, selection []string) ([]*dataColumn, error) {
+  if selection == nil {
+    cols := make([]*dataColumn, len(header))
+    for i, name := range header {
+      cols[i] = &dataColumn{name: name, index: i, numeric: true}
+    }
+    return cols, nil
+  }
+
+  var cols []*dataColumn
+  for _, sel := range selection {
+    index := -1
+    for i, name := range header {
+      if name == sel {
+        index = i
+        break
+      }
+    }
+    if n, err := strconv.Atoi(sel); index == -1 && err == nil &&
+      n >= 1 && n <= len(header) {
+      index = n - 1
+    }
+    if index == -1 {
+      return nil, fmt.Errorf("no column %s", sel)
+    }
+    cols = append(cols,
+      &dataColumn{name: header[index], index: index, numeric: true})
+  }
+  return cols, nil
+}
+
+// readColumns reads the records after the header into the values of
+// the columns. Empty fields are missing values. A column with a field
+// that is not a number is marked as non-numeric, unless it was selected
+// explicitly, in which case, like for a number out of range, the field
+// is skipped with reader.Skip if set and an error is returned otherwise.
+func readColumns(cr *csv.Reader, cols []*dataColumn, reader *stats.Reader, selected bool) error {
+  for {
+    record, err := cr.Read()
+    if err == io.EOF {
+      return nil
+    }
+    if err != nil {
+      return err
+    }
+    line, _ := cr.FieldPos(0)
+
+    for _, c := range cols {
+      text := strings.TrimSpace(record[c.index])
+      x, err := math.NaN(), error(nil)
+      if text != "" {
+        x, err = stats.ParseFloat(text, reader.Range)
+      }
+      if errors.Is(err, stats.ErrNotNumber) && !selected {
+        c.numeric = false
+      } else if err != nil {
+        e := &stats.ParseError{Line: line, Text: text, Err: err}
+        if reader.Skip == nil {
+          return fmt.Errorf("column %s: %w", c.name, e)
+        }
+        reader.Skip(e)
+        x = math.NaN()
+      }
+      c.values = append(c.values, x)
+    }
+  }
+}
+
+// describeColumns returns the report of the metrics of every column and
+// the matrix of correlations between them.
+func describeColumns(cols []*dataColumn) report {
+  var sel []*metric
+  for _, name := range describeMetrics {
+    for _, m := range metrics {
+      if m.name == name {
+        sel = append(sel, m)
+      }
+    }
+  }
+  for _, p := range percentilesFlag {
+    sel = append(sel, percentile(p))
+  }
+
+  describe := table{key: "describe", title: "Columns", label: "column"}
+  for _, m := range sel {
+    describe.columns = append(describe.columns, column{m.name, m.exact})
+  }
+  corr := table{key: "correlation", title: "Pearson correlation",
+    label: "column"}
+  for _, c := range cols {
+    describe.labels = append(describe.labels, c.name)
+    describe.rows = append(describe.rows,
+      values(sel, stats.NewSample(present(c.values))))
+
+    corr.columns = append(corr.columns, column{c.name, false})
+    corr.labels = append(corr.labels, c.name)
+    row := make([]float64, len(cols))
+    for j, d := range cols {
+      row[j] = correlation(c.values, d.values)
+    }
+    corr.rows = append(corr.rows, row)
+  }
+  return report{tables: []table{describe, corr}}
+}
+
+// present returns the values that are not missing.
+func present(xs []float64) []float64 {
+  var p []float64
+  for _, x := range xs {
+    if !math.IsNaN(x) {
+      p = append(p, x)
+    }
+  }
+  return p
+}
+
+// correlation returns the Pearson correlation of the rows in which
+// neither value is missing.
+func correlation(xs, ys []float64) float64 {
+  var px, py []float64
+  for i := range xs {
+    if !math.IsNaN(xs[i]) && !math.IsNaN(ys[i]) {
+      px, py = append(px, xs[i]), append(py, ys[i])
+    }
+  }
+  return stats.Pearson(px, py)
+}
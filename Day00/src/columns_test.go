@@ -0,0 +1,169 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/csv"
+  "errors"
+  "math"
+  "slices"
+  "strings"
+  "testing"
+)
+
+// equalValues reports whether the values are equal, with NaN equal to
+// NaN as a missing value.
+func equalValues(xs, ys []float64) bool {
+  if len(xs) != len(ys) {
+    return false
+  }
+  for i := range xs {
+    if xs[i] != ys[i] && !(math.IsNaN(xs[i]) && math.IsNaN(ys[i])) {
+      return false
+    }
+  }
+  return true
+}
+
+func TestSelectColumns(t *testing.T) {
+  header := []string{"a", "b", "1", "c"}
+  tests := []struct {
+    name      string
+    selection []string
+    want      []int
+    err       bool
+  }{
+    {"all", nil, []int{0, 1, 2, 3}, false},
+    {"names", []string{"c", "a"}, []int{3, 0}, false},
+    {"indices", []string{"2", "4"}, []int{1, 3}, false},
+    {"name before index", []string{"1"}, []int{2}, false},
+    {"unknown name", []string{"a", "d"}, nil, true},
+    {"index out of range", []string{"5"}, nil, true},
+    {"zero index", []string{"0"}, nil, true},
+  }
+
+  for _, tt := range tests {
+    cols, err := selectColumns(header, tt.selection)
+    if (err != nil) != tt.err {
+      t.Errorf("%s: err = %v", tt.name, err)
+      continue
+    }
+    var got []int
+    for _, c := range cols {
+      got = append(got, c.index)
+      if c.name != header[c.index] || !c.numeric {
+        t.Errorf("%s: got column %+v", tt.name, c)
+      }
+    }
+    if !slices.Equal(got, tt.want) {
+      t.Errorf("%s: got columns %v, want %v", tt.name, got, tt.want)
+    }
+  }
+}
+
+func TestReadColumns(t *testing.T) {
+  nan := math.NaN()
+  tests := []struct {
+    name      string
+    in        string
+    selection []string
+    skip      bool
+    want      [][]float64 // nil for a non-numeric column
+    skipped   int
+    err       error
+  }{
+    {"numbers", "a,b\n1,2\n3,4\n", nil, false,
+      [][]float64{{1, 3}, {2, 4}}, 0, nil},
+    {"missing", "a,b\n1,\n,4\n 5 , 6\n", nil, false,
+      [][]float64{{1, nan, 5}, {nan, 4, 6}}, 0, nil},
+    {"non-numeric", "a,name\n1,x\n2,3\n", nil, false,
+      [][]float64{{1, 2}, nil}, 0, nil},
+    {"selected non-numeric", "a,name\n1,x\n2,3\n", []string{"name"}, false,
+      nil, 0, stats.ErrNotNumber},
+    {"skip selected non-numeric", "a,name\n1,x\n2,3\n", []string{"name"}, true,
+      [][]float64{{nan, 3}}, 1, nil},
+    {"out of range", "a\n1\n1e9\n", nil, false, nil, 0, stats.ErrRange},
+    {"skip out of range", "a\n1\n1e9\n", nil, true, [][]float64{{1, nan}}, 1, nil},
+  }
+
+  for _, tt := range tests {
+    cr := csv.NewReader(strings.NewReader(tt.in))
+    cr.TrimLeadingSpace = true
+    header, _ := cr.Read()
+    cols, err := selectColumns(header, tt.selection)
+    if err != nil {
+      t.Fatalf("%s: %v", tt.name, err)
+    }
+
+    skipped := 0
+    reader := &stats.Reader{Range: stats.Range{Min: -100, Max: 100}}
+    if tt.skip {
+      reader.Skip = func(*stats.ParseError) { skipped++ }
+    }
+    err = readColumns(cr, cols, reader, tt.selection != nil)
+    if !errors.Is(err, tt.err) || skipped != tt.skipped {
+      t.Errorf("%s: err = %v, skipped %d, want %v, %d",
+        tt.name, err, skipped, tt.err, tt.skipped)
+      continue
+    }
+    if err != nil {
+      continue
+    }
+    for i, c := range cols {
+      if tt.want[i] == nil {
+        if c.numeric {
+          t.Errorf("%s: column %s is numeric", tt.name, c.name)
+        }
+      } else if !c.numeric || !equalValues(c.values, tt.want[i]) {
+        t.Errorf("%s: column %s = %v, numeric %v, want %v",
+          tt.name, c.name, c.values, c.numeric, tt.want[i])
+      }
+    }
+  }
+}
+
+func TestCorrelation(t *testing.T) {
+  nan := math.NaN()
+  tests := []struct {
+    name   string
+    xs, ys []float64
+    want   float64
+  }{
+    {"complete", []float64{1, 2, 3}, []float64{2, 4, 6}, 1},
+    {"negative", []float64{1, 2, 3}, []float64{3, 2, 1}, -1},
+    {"pairwise complete", []float64{1, nan, 3, 4}, []float64{3, 9, nan, 2}, -1},
+    {"no complete pairs", []float64{1, nan}, []float64{nan, 2}, nan},
+  }
+
+  for _, tt := range tests {
+    got := correlation(tt.xs, tt.ys)
+    if !equalValues([]float64{got}, []float64{tt.want}) &&
+      math.Abs(got-tt.want) > 1e-9 {
+      t.Errorf("%s: correlation = %v, want %v", tt.name, got, tt.want)
+    }
+  }
+
+  if got := present([]float64{nan, 1, nan, 2}); !equalValues(got, []float64{1, 2}) {
+    t.Errorf("present = %v, want [1 2]", got)
+  }
+}
+
+func TestDescribeColumns(t *testing.T) {
+  nan := math.NaN()
+  cols := []*dataColumn{
+    {name: "a", values: []float64{1, 2, nan, 5}, numeric: true},
+    {name: "b", values: []float64{2, 4, 6, nan}, numeric: true},
+  }
+  r := describeColumns(cols)
+  if len(r.tables) != 2 {
+    t.Fatalf("got %d tables, want 2", len(r.tables))
+  }
+
+  describe, corr := r.tables[0], r.tables[1]
+  if describe.columns[0].key != "count" || describe.rows[0][0] != 3 ||
+    describe.rows[1][0] != 3 || describe.rows[0][1] != 8.0/3 {
+    t.Errorf("describe = %v %v", describe.columns, describe.rows)
+  }
+  if corr.rows[0][1] != 1 || corr.rows[1][0] != 1 || corr.labels[1] != "b" {
+    t.Errorf("correlation = %v %v", corr.labels, corr.rows)
+  }
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "os"
+)
+
+var aFlag, bFlag string
+
+func init() {
+  flag.StringVar(&aFlag, "a", "",
+    "A string. File of the first sample to compare, - for stdin")
+  flag.StringVar(&bFlag, "b", "",
+    "A string. File of the second sample to compare, - for stdin;\n"+
+      "the samples may also be given as two arguments")
+}
+
+// compare calculates the metrics of the two samples read from files and
+// tests whether they differ.
+func compare(reader *stats.Reader, fileA, fileB string) int {
+  a, err := readFile(reader, fileA)
+  if err != nil {
+    return exitInvalidInput
+  }
+  b, err := readFile(reader, fileB)
+  if err != nil {
+    return exitInvalidInput
+  }
+  if len(a) < 2 || len(b) < 2 {
+    fmt.Fprintln(os.Stderr, "Incorrect input: each sample must have at least two numbers")
+    return exitInvalidInput
+  }
+
+  sel := selected()
+  t := table{key: "samples", title: "Samples", label: "sample",
+    labels: []string{fileA, fileB}}
+  for _, m := range sel {
+    t.columns = append(t.columns, column{m.name, m.exact})
+  }
+  t.rows = [][]float64{
+    values(sel, stats.NewSample(a)),
+    values(sel, stats.NewSample(b)),
+  }
+
+  var r report
+  meanA, meanB := stats.Mean(a), stats.Mean(b)
+  r.add("difference", "Difference of means", meanB-meanA)
+  r.add("difference_pct", "Difference %", (meanB-meanA)/meanA*100)
+  r.add("cohens_d", "Cohen's d", stats.CohensD(a, b))
+
+  welch := stats.WelchTTest(a, b)
+  r.addTest("welch_t", "Welch's t", welch.Statistic)
+  r.add("welch_df", "Welch's df", welch.DF)
+  r.addTest("welch_p", "Welch's p-value", welch.P)
+
+  mw := stats.MannWhitneyU(a, b)
+  r.addTest("mann_whitney_u", "Mann-Whitney U", mw.Statistic)
+  r.addTest("mann_whitney_p", "Mann-Whitney p-value", mw.P)
+
+  ks := stats.KolmogorovSmirnov(a, b)
+  r.addTest("ks_d", "Kolmogorov-Smirnov D", ks.Statistic)
+  r.addTest("ks_p", "Kolmogorov-Smirnov p-value", ks.P)
+
+  r.tables = append(r.tables, t)
+  return r.print()
+}
+
+// readFile reads the numbers of the file, or of stdin if the name is -.
+// It prints the error, if any.
+func readFile(reader *stats.Reader, name string) ([]float64, error) {
+  file := os.Stdin
+  if name != "-" {
+    var err error
+    if file, err = os.Open(name); err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return nil, err
+    }
+    defer file.Close()
+  }
+
+  nums, err := reader.ReadFloats(file)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "%s: %s\n", name, errmsg(err, reader.Range))
+  }
+  return nums, err
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "math"
+  "math/big"
+  "os"
+)
+
+// exactDigits is the number of decimals of results that have no finite
+// decimal representation, like 1/3, printed with -precision -1.
+const exactDigits int = 20
+
+// sqrtPrec is the precision in bits of the square roots in exact mode.
+const sqrtPrec uint = 256
+
+var exactFlag bool
+
+func init() {
+  flag.BoolVar(&exactFlag, "exact", false,
+    "A bool. Parse the input as decimals and compute without rounding,\n"+
+      "the SD is rounded only in the output\n"+
+      "(only count, sum, mean, median, mode, deviation, variance,\n"+
+      "minimum, maximum and range are available)")
+}
+
+// exactMetrics are the metrics available in exact mode.
+var exactMetrics = map[string]func(s *stats.ExactSample) *big.Rat{
+  "count": func(s *stats.ExactSample) *big.Rat {
+    return new(big.Rat).SetInt64(int64(s.Len()))
+  },
+  "sum":    (*stats.ExactSample).Sum,
+  "mean":   (*stats.ExactSample).Mean,
+  "median": (*stats.ExactSample).Median,
+  "mode":   (*stats.ExactSample).Mode,
+  "variance": func(s *stats.ExactSample) *big.Rat {
+    return s.Variance(ddofFlag)
+  },
+  "minimum": (*stats.ExactSample).Min,
+  "maximum": (*stats.ExactSample).Max,
+  "range":   (*stats.ExactSample).Range,
+}
+
+// exact computes the selected metrics of decimal input with rational
+// arithmetic.
+func exact(reader *stats.Reader) int {
+  nums, err := reader.ReadDecimals(os.Stdin)
+  if err == nil && len(nums) == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintln(os.Stderr, errmsg(err, reader.Range))
+    return exitInvalidInput
+  }
+  sample := stats.NewExactSample(nums)
+
+  var r report
+  for _, m := range selected() {
+    f := m.field(math.NaN())
+    if value, ok := exactMetrics[m.name]; ok {
+      if x := value(sample); x != nil {
+        f.value, _ = x.Float64()
+        f.text = formatRat(x, m.exact)
+      }
+    } else if m.name == "deviation" {
+      if x := sample.StdDev(ddofFlag, sqrtPrec); x != nil {
+        f.value, _ = x.Float64()
+        f.text = x.Text('f', decimals())
+      }
+    } else {
+      continue
+    }
+    r.fields = append(r.fields, f)
+  }
+  return r.print()
+}
+
+// formatRat formats x with the precision set by -precision, or exactly
+// if it has a finite decimal representation.
+func formatRat(x *big.Rat, exact bool) string {
+  if !exact && precisionFlag != -1 {
+    return x.FloatString(precisionFlag)
+  }
+
+  // The representation is finite if the denominator has no prime
+  // factors but 2 and 5, as many decimals as the larger power.
+  d := new(big.Int).Set(x.Denom())
+  twos := d.TrailingZeroBits()
+  d.Rsh(d, twos)
+  var fives uint
+  five, m := big.NewInt(5), new(big.Int)
+  for {
+    q, r := new(big.Int).QuoRem(d, five, m)
+    if r.Sign() != 0 {
+      break
+    }
+    d, fives = q, fives+1
+  }
+  if d.Cmp(big.NewInt(1)) != 0 {
+    return x.FloatString(decimals())
+  }
+  return x.FloatString(int(max(twos, fives)))
+}
+
+// decimals returns the number of decimals of the inexact results.
+func decimals() int {
+  if precisionFlag == -1 {
+    return exactDigits
+  }
+  return precisionFlag
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "math"
+  "os"
+  "sort"
+)
+
+// overall is the label of the row of all groups together.
+const overall string = "(all)"
+
+var (
+  groupFlag int
+  sortFlag  string = "group"
+  descFlag  bool
+)
+
+func init() {
+  flag.IntVar(&groupFlag, "group", 0,
+    "An int. Read lines of a label and a number and calculate the metrics\n"+
+      "per label; the value is the column of the label, 1 or 2")
+  flag.StringVar(&sortFlag, "sort", "group",
+    "A string. Sort the groups by the label (group) or by a metric")
+  flag.BoolVar(&descFlag, "desc", false,
+    "A bool. Sort the groups in descending order")
+}
+
+// groups calculates the selected metrics for every group of labeled
+// input and for the whole input.
+func groups(reader *stats.Reader) int {
+  labels, nums, err := reader.ReadLabeled(os.Stdin, groupFlag-1)
+  if err == nil && len(nums) == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected a non-empty sequence of labels and numbers,"+
+      " separated by newlines\n", err)
+    return exitInvalidInput
+  }
+
+  members := make(map[string][]float64)
+  for i, label := range labels {
+    members[label] = append(members[label], nums[i])
+  }
+
+  sel := selected()
+  t := table{key: "groups", title: "Groups", label: "group"}
+  for _, m := range sel {
+    t.columns = append(t.columns, column{m.name, m.exact})
+  }
+  for label, xs := range members {
+    t.labels = append(t.labels, label)
+    t.rows = append(t.rows, values(sel, stats.NewSample(xs)))
+  }
+
+  key := -1
+  for i, m := range sel {
+    if m.name == sortFlag {
+      key = i
+    }
+  }
+  if key == -1 && sortFlag != "group" {
+    fmt.Fprintf(os.Stderr, "-sort: %s is not a selected metric\n", sortFlag)
+    return exitUsage
+  }
+  sort.Sort(byColumn{&t, key, descFlag})
+
+  t.labels = append(t.labels, overall)
+  t.rows = append(t.rows, values(sel, stats.NewSample(nums)))
+
+  r := report{tables: []table{t}}
+  return r.print()
+}
+
+// values returns the values of the metrics of the sample.
+func values(metrics []*metric, s *stats.Sample) []float64 {
+  row := make([]float64, len(metrics))
+  for i, m := range metrics {
+    row[i] = m.value(s)
+  }
+  return row
+}
+
+// byColumn sorts the rows of a table by the values in a column,
+// or by the labels if the column is -1, in descending order if desc is
+// set. NaN values sort last either way and equal values by their
+// labels.
+type byColumn struct {
+  t      *table
+  column int
+  desc   bool
+}
+
+func (b byColumn) Len() int {
+  return len(b.t.rows)
+}
+
+func (b byColumn) Less(i, j int) bool {
+  if b.column == -1 {
+    return b.t.labels[i] < b.t.labels[j] != b.desc
+  }
+  x, y := b.t.rows[i][b.column], b.t.rows[j][b.column]
+  if xNaN, yNaN := math.IsNaN(x), math.IsNaN(y); xNaN || yNaN {
+    if xNaN && yNaN {
+      return b.t.labels[i] < b.t.labels[j]
+    }
+    return yNaN
+  }
+  if x == y {
+    return b.t.labels[i] < b.t.labels[j]
+  }
+  return x < y != b.desc
+}
+
+func (b byColumn) Swap(i, j int) {
+  b.t.rows[i], b.t.rows[j] = b.t.rows[j], b.t.rows[i]
+  b.t.labels[i], b.t.labels[j] = b.t.labels[j], b.t.labels[i]
+}
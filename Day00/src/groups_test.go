@@ -0,0 +1,34 @@
+package main
+
+import (
+  "math"
+  "slices"
+  "sort"
+  "testing"
+)
+
+func TestByColumn(t *testing.T) {
+  nan := math.NaN()
+  tests := []struct {
+    name   string
+    column int
+    desc   bool
+    want   []string
+  }{
+    {"labels", -1, false, []string{"a", "b", "c", "d", "e"}},
+    {"labels desc", -1, true, []string{"e", "d", "c", "b", "a"}},
+    {"values", 0, false, []string{"d", "a", "e", "b", "c"}},
+    {"values desc", 0, true, []string{"a", "e", "d", "b", "c"}},
+  }
+
+  for _, tt := range tests {
+    tbl := table{
+      labels: []string{"c", "e", "a", "d", "b"},
+      rows:   [][]float64{{nan}, {2}, {2}, {1}, {nan}},
+    }
+    sort.Sort(byColumn{&tbl, tt.column, tt.desc})
+    if !slices.Equal(tbl.labels, tt.want) {
+      t.Errorf("%s: got %v, want %v", tt.name, tbl.labels, tt.want)
+    }
+  }
+}
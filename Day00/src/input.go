@@ -0,0 +1,74 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "fmt"
+  "io"
+  "sort"
+  "strings"
+)
+
+// maxExamples limits the skipped lines quoted in the summary.
+const maxExamples int = 10
+
+var errNoData = errors.New("no valid numbers")
+
+// errmsg explains why the input of numbers within rg was rejected.
+func errmsg(err error, rg stats.Range) string {
+  bounds := ""
+  if rg != stats.Unbounded {
+    bounds = fmt.Sprintf("\nbetween %g and %g,", rg.Min, rg.Max)
+  }
+  return fmt.Sprintf("Incorrect input: %v\n", err) +
+    "expected a non-empty sequence of numbers," + bounds +
+    " separated by newlines"
+}
+
+// skipReport collects the lines ignored with -skip-invalid.
+type skipReport struct {
+  total    int
+  reasons  map[string]int
+  examples []*stats.ParseError
+}
+
+// newReader returns a reader of numbers within rg. If skipInvalid is
+// set, invalid lines are recorded in the returned report and ignored.
+func newReader(rg stats.Range, skipInvalid bool) (*stats.Reader, *skipReport) {
+  reader := &stats.Reader{Range: rg}
+  report := &skipReport{reasons: make(map[string]int)}
+  if skipInvalid {
+    reader.Skip = report.add
+  }
+  return reader, report
+}
+
+func (s *skipReport) add(e *stats.ParseError) {
+  s.total++
+  s.reasons[e.Err.Error()]++
+  if len(s.examples) < maxExamples {
+    s.examples = append(s.examples, e)
+  }
+}
+
+// print writes the summary of skipped lines, if there were any.
+func (s *skipReport) print(w io.Writer) {
+  if s.total == 0 {
+    return
+  }
+
+  reasons := make([]string, 0, len(s.reasons))
+  for reason, count := range s.reasons {
+    reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
+  }
+  sort.Strings(reasons)
+  fmt.Fprintf(w, "Skipped %d invalid lines: %s\n",
+    s.total, strings.Join(reasons, ", "))
+
+  for _, e := range s.examples {
+    fmt.Fprintf(w, "  %v\n", e)
+  }
+  if s.total > len(s.examples) {
+    fmt.Fprintf(w, "  ... and %d more\n", s.total-len(s.examples))
+  }
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "flag"
+  "fmt"
+  "math"
+  "strconv"
+  "strings"
+)
+
+// metric is a statistic of a sample enabled by the flag of its name.
+type metric struct {
+  name  string // flag name
+  label string // name in the output
+  def   bool   // enabled by default
+  usage string
+  exact bool // printed without rounding
+  ddof  bool // depends on -ddof, noted in the output
+  value func(s *stats.Sample) float64
+
+  enabled *bool
+}
+
+var metrics = []*metric{
+  {name: "count", label: "Count", usage: "count of numbers", exact: true,
+    value: func(s *stats.Sample) float64 { return float64(s.Len()) }},
+  {name: "sum", label: "Sum", usage: "sum",
+    value: (*stats.Sample).Sum},
+  {name: "mean", label: "Mean", def: true, usage: "mean",
+    value: (*stats.Sample).Mean},
+  {name: "trimmed", label: "Trimmed mean", usage: "trimmed mean",
+    value: func(s *stats.Sample) float64 { return s.TrimmedMean(cutFlag) }},
+  {name: "winsorized", label: "Winsorized mean", usage: "winsorized mean",
+    value: func(s *stats.Sample) float64 { return s.WinsorizedMean(cutFlag) }},
+  {name: "median", label: "Median", def: true, usage: "median",
+    value: (*stats.Sample).Median},
+  {name: "mode", label: "Mode", def: true, usage: "mode", exact: true,
+    value: (*stats.Sample).Mode},
+  {name: "deviation", label: "SD", def: true, usage: "deviation",
+    ddof: true,
+    value: func(s *stats.Sample) float64 {
+      return math.Sqrt(s.Variance(ddofFlag))
+    }},
+  {name: "variance", label: "Variance", usage: "variance", ddof: true,
+    value: func(s *stats.Sample) float64 { return s.Variance(ddofFlag) }},
+  {name: "sem", label: "SEM", usage: "standard error of the mean",
+    ddof:  true,
+    value: func(s *stats.Sample) float64 { return s.StdErr(ddofFlag) }},
+  {name: "minimum", label: "Min", usage: "minimum", exact: true,
+    value: (*stats.Sample).Min},
+  {name: "maximum", label: "Max", usage: "maximum", exact: true,
+    value: (*stats.Sample).Max},
+  {name: "range", label: "Range", usage: "range",
+    value: (*stats.Sample).Range},
+  {name: "q1", label: "Q1", usage: "the first quartile",
+    value: func(s *stats.Sample) float64 { return s.Quantile(0.25) }},
+  {name: "q3", label: "Q3", usage: "the third quartile",
+    value: func(s *stats.Sample) float64 { return s.Quantile(0.75) }},
+  {name: "iqr", label: "IQR", usage: "interquartile range",
+    value: (*stats.Sample).IQR},
+  {name: "mad", label: "MAD", usage: "median absolute deviation",
+    value: (*stats.Sample).MAD},
+  {name: "cv", label: "CV", usage: "coefficient of variation", ddof: true,
+    value: func(s *stats.Sample) float64 {
+      return math.Sqrt(s.Variance(ddofFlag)) / s.Mean()
+    }},
+  {name: "skewness", label: "Skewness", usage: "skewness",
+    value: (*stats.Sample).Skewness},
+  {name: "kurtosis", label: "Kurtosis", usage: "excess kurtosis",
+    value: (*stats.Sample).Kurtosis},
+}
+
+// percentiles are the values of -p, from 0 to 100.
+type percentiles []float64
+
+func (p *percentiles) String() string {
+  return fmt.Sprint(*p)
+}
+
+func (p *percentiles) Set(value string) error {
+  for _, s := range strings.Split(value, ",") {
+    x, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+    if err != nil || !(x >= 0 && x <= 100) {
+      return errors.New("expected percentages between 0 and 100")
+    }
+    *p = append(*p, x)
+  }
+  return nil
+}
+
+var percentilesFlag percentiles
+var allFlag bool
+
+// ddofFlag is the delta degrees of freedom of the variance:
+// the denominator is the sample size minus ddofFlag.
+var ddofFlag int
+
+func init() {
+  for _, m := range metrics {
+    m.enabled = flag.Bool(m.name, m.def,
+      "A bool. Unable/disable calculation of "+m.usage)
+  }
+  flag.Var(&percentilesFlag, "p",
+    "A list. Comma separated percentiles to calculate, e.g. 90,95,99")
+  flag.BoolVar(&allFlag, "all", false,
+    "A bool. Enable calculation of all metrics")
+  flag.IntVar(&ddofFlag, "ddof", 0,
+    "An int. Delta degrees of freedom of the variance and SD:\n"+
+      "0 for the population, 1 for the sample estimate")
+  flag.BoolFunc("sample", "Same as -ddof 1, or -ddof 0 if false", func(s string) error {
+    sample, err := strconv.ParseBool(s)
+    if err != nil {
+      return err
+    }
+    ddofFlag = 0
+    if sample {
+      ddofFlag = 1
+    }
+    return nil
+  })
+}
+
+// percentile returns the metric of the p-th percentile.
+func percentile(p float64) *metric {
+  name := strconv.FormatFloat(p, 'f', -1, 64)
+  return &metric{
+    name:  "p" + name,
+    label: "P" + name,
+    value: func(s *stats.Sample) float64 { return s.Quantile(p / 100) },
+  }
+}
+
+// quantiles returns the fractions of the quantile metrics by name:
+// the median, the quartiles and the percentiles of -p.
+func quantiles() map[string]float64 {
+  q := map[string]float64{"median": 0.5, "q1": 0.25, "q3": 0.75}
+  for _, p := range percentilesFlag {
+    q[percentile(p).name] = p / 100
+  }
+  return q
+}
+
+// selected returns the metrics enabled by the flags, followed by
+// the requested percentiles.
+func selected() []*metric {
+  var sel []*metric
+  for _, m := range metrics {
+    if *m.enabled || allFlag {
+      sel = append(sel, m)
+    }
+  }
+  for _, p := range percentilesFlag {
+    sel = append(sel, percentile(p))
+  }
+  return sel
+}
+
+// title returns the name of the metric in the output.
+func (m *metric) title() string {
+  if !m.ddof {
+    return m.label
+  }
+  switch ddofFlag {
+  case 0:
+    return m.label + " (population)"
+  case 1:
+    return m.label + " (sample)"
+  }
+  return fmt.Sprintf("%s (ddof=%d)", m.label, ddofFlag)
+}
+
+// field returns the field of the report with the value of the metric.
+func (m *metric) field(x float64) field {
+  return field{key: m.name, title: m.title(), value: x, exact: m.exact}
+}
+
+// enabled reports whether the metric of the name is selected.
+func enabled(name string) bool {
+  for _, m := range metrics {
+    if m.name == name {
+      return *m.enabled || allFlag
+    }
+  }
+  return false
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "os"
+  "strings"
+)
+
+var normalityFlag bool
+
+func init() {
+  flag.BoolVar(&normalityFlag, "normality", false,
+    "A bool. Test whether the input is normally distributed and fit\n"+
+      "normal, log-normal, exponential and uniform distributions to it")
+}
+
+// normality tests the input for normality and ranks the distributions
+// fitted to it by maximum likelihood from the best to the worst.
+func normality(reader *stats.Reader) int {
+  nums, err := reader.ReadFloats(os.Stdin)
+  if err == nil && len(nums) < 3 {
+    err = fmt.Errorf("%d valid numbers", len(nums))
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected at least three numbers, separated by newlines\n", err)
+    return exitInvalidInput
+  }
+
+  var r report
+  sw := stats.ShapiroWilk(nums)
+  r.addTest("shapiro_wilk_w", "Shapiro-Wilk W", sw.Statistic)
+  r.addTest("shapiro_wilk_p", "Shapiro-Wilk p-value", sw.P)
+  if len(nums) > 5000 {
+    fmt.Fprintln(os.Stderr,
+      "Warning: the Shapiro-Wilk test is limited to 5000 numbers")
+  }
+  ad := stats.AndersonDarling(nums)
+  r.addTest("anderson_darling_a2", "Anderson-Darling A²", ad.Statistic)
+  r.addTest("anderson_darling_p", "Anderson-Darling p-value", ad.P)
+  jb := stats.JarqueBera(nums)
+  r.addTest("jarque_bera", "Jarque-Bera", jb.Statistic)
+  r.addTest("jarque_bera_p", "Jarque-Bera p-value", jb.P)
+
+  fits := stats.FitDistributions(nums)
+  t := table{key: "fits", title: "Fits, best first", label: "distribution",
+    columns: []column{{"log_likelihood", false}, {"aic", false}, {"ks", false}}}
+  for _, f := range fits {
+    for _, p := range f.Params {
+      r.add(f.Name+"_"+p.Name,
+        strings.ToUpper(f.Name[:1])+f.Name[1:]+" "+p.Name, p.Value)
+    }
+    t.labels = append(t.labels, f.Name)
+    t.rows = append(t.rows, []float64{f.LogLikelihood, f.AIC, f.KS})
+  }
+  r.tables = append(r.tables, t)
+  return r.print()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "flag"
+  "math"
+)
+
+// outlierRules are the values of -outliers with the default thresholds.
+var outlierRules = map[string]struct {
+  rule      stats.OutlierRule
+  threshold float64
+}{
+  "tukey":   {stats.Tukey, 1.5},
+  "zscore":  {stats.ZScore, 3},
+  "mzscore": {stats.ModifiedZScore, 3.5},
+}
+
+var (
+  outliersFlag  string
+  thresholdFlag float64 = math.NaN()
+  trimFlag      bool
+  cutFlag       float64 = 0.1
+)
+
+func init() {
+  flag.Func("outliers",
+    "A string. Report outliers found by a rule: tukey (IQR fences),\n"+
+      "zscore or mzscore (modified z-score based on MAD)",
+    func(s string) error {
+      if _, ok := outlierRules[s]; !ok {
+        return errors.New("expected tukey, zscore or mzscore")
+      }
+      outliersFlag = s
+      return nil
+    })
+  flag.Func("threshold",
+    "A float. Threshold of the outlier rule\n"+
+      "(default 1.5 for tukey, 3 for zscore, 3.5 for mzscore)",
+    func(s string) (err error) {
+      thresholdFlag, err = stats.ParseFloat(s, stats.Range{Min: 0, Max: math.Inf(1)})
+      return err
+    })
+  flag.BoolVar(&trimFlag, "trim", false,
+    "A bool. Remove the outliers before calculating the metrics\n"+
+      "(tukey rule unless -outliers is set)")
+  flag.Float64Var(&cutFlag, "cut", 0.1,
+    "A float. Fraction cut from each end for the trimmed and winsorized means")
+}
+
+// findOutliers reports the outliers of nums read from the lines, if
+// requested by the flags, and returns nums without them if -trim is set.
+func (r *report) findOutliers(nums []float64, lines []int) []float64 {
+  if outliersFlag == "" && !trimFlag {
+    return nums
+  }
+  if outliersFlag == "" {
+    outliersFlag = "tukey"
+  }
+  rule := outlierRules[outliersFlag]
+  if math.IsNaN(thresholdFlag) {
+    thresholdFlag = rule.threshold
+  }
+
+  idx, scores := stats.Outliers(nums, rule.rule, thresholdFlag)
+  t := table{key: "outliers", title: "Outliers", columns: []column{
+    {"line", true}, {"value", true}, {"score", false},
+  }}
+  for i, j := range idx {
+    t.rows = append(t.rows, []float64{float64(lines[j]), nums[j], scores[i]})
+  }
+  r.tables = append(r.tables, t)
+
+  if !trimFlag || len(idx) == 0 {
+    return nums
+  }
+  r.fields = append(r.fields, field{key: "outliers_removed",
+    title: "Outliers removed", value: float64(len(idx)), exact: true})
+
+  kept := make([]float64, 0, len(nums)-len(idx))
+  for i, num := range nums {
+    if len(idx) != 0 && idx[0] == i {
+      idx = idx[1:]
+      continue
+    }
+    kept = append(kept, num)
+  }
+  return kept
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/csv"
+  "encoding/json"
+  "errors"
+  "flag"
+  "fmt"
+  "io"
+  "math"
+  "os"
+  "strconv"
+  "strings"
+  "text/tabwriter"
+)
+
+// Exit statuses of the program.
+const (
+  exitOK           = 0
+  exitInvalidInput = 1
+  exitUsage        = 2
+  exitFailure      = 3
+  exitRegression   = 4 // a metric moved beyond -tolerance of -compare
+)
+
+// field is a value of the report.
+type field struct {
+  key   string // stable name in machine-readable formats
+  title string // name in the text format
+  value float64
+  exact bool   // printed without rounding
+  text  string // formatted value, printed instead of value if set
+  // significant reports whether the value is a test statistic or
+  // a p-value, printed with significant digits rather than decimals.
+  significant bool
+  // setting reports whether the field describes the run rather than
+  // the data, so it is left out of baselines.
+  setting bool
+
+  // estimate reports whether lo and hi bound the exact value.
+  estimate bool
+  lo, hi   float64
+}
+
+// column is a column of a table in the report.
+type column struct {
+  key   string
+  exact bool
+}
+
+// table is a list of rows in the report.
+type table struct {
+  key     string // stable name in machine-readable formats
+  title   string // name in the text format
+  columns []column
+  rows    [][]float64
+
+  // label is the name of the first column holding the labels of
+  // the rows, if they have labels.
+  label  string
+  labels []string
+}
+
+// format returns the text of the field, or its value formatted by number.
+func (f *field) format(number func(x float64, exact bool) string) string {
+  if f.text != "" {
+    return f.text
+  }
+  if f.significant && !math.IsNaN(f.value) && !math.IsInf(f.value, 0) {
+    digits := significantDigits
+    if precisionFlag == -1 {
+      digits = -1
+    }
+    return strconv.FormatFloat(f.value, 'g', digits, 64)
+  }
+  return number(f.value, f.exact)
+}
+
+// header returns the names of the columns.
+func (t *table) header() []string {
+  var names []string
+  if t.label != "" {
+    names = append(names, t.label)
+  }
+  for _, c := range t.columns {
+    names = append(names, c.key)
+  }
+  return names
+}
+
+// cells returns the label and the values of the i-th row, the values
+// formatted by number.
+func (t *table) cells(i int, number func(x float64, exact bool) string) []string {
+  var cells []string
+  if t.label != "" {
+    cells = append(cells, t.labels[i])
+  }
+  for j, c := range t.columns {
+    cells = append(cells, number(t.rows[i][j], c.exact))
+  }
+  return cells
+}
+
+// report is the output of the program.
+type report struct {
+  fields []field
+  tables []table
+  charts []string // drawn in the text format only
+}
+
+var formatFlag string = "text"
+var precisionFlag int = 2
+
+// significantDigits is the number of significant digits of test
+// statistics and p-values, which are printed in full with -precision -1.
+const significantDigits int = 4
+
+func init() {
+  flag.Func("format",
+    "A string. Output format: text, json, csv or tsv (default text)",
+    func(s string) error {
+      switch s {
+      case "text", "json", "csv", "tsv":
+        formatFlag = s
+        return nil
+      }
+      return errors.New("expected text, json, csv or tsv")
+    })
+  flag.IntVar(&precisionFlag, "precision", 2,
+    "An int. Number of decimals in the output, -1 for the shortest exact")
+}
+
+// add adds a field to the report.
+func (r *report) add(key, title string, value float64) {
+  r.fields = append(r.fields, field{key: key, title: title, value: value})
+}
+
+// addTest adds a field of a test statistic or a p-value to the report.
+func (r *report) addTest(key, title string, value float64) {
+  r.fields = append(r.fields,
+    field{key: key, title: title, value: value, significant: true})
+}
+
+// addFrequencies adds a table of the frequencies to the report.
+func (r *report) addFrequencies(key, title string, freq []stats.Frequency) {
+  t := table{key: key, title: title, columns: []column{
+    {"value", true}, {"count", true}, {"relative", false},
+    {"cumulative", true}, {"cum_relative", false},
+  }}
+  for _, f := range freq {
+    t.rows = append(t.rows, []float64{f.Value, float64(f.Count),
+      f.Relative, float64(f.Cumulative), f.CumRelative})
+  }
+  r.tables = append(r.tables, t)
+}
+
+// write writes the report in the format selected by -format.
+func (r *report) write(w io.Writer) error {
+  switch formatFlag {
+  case "json":
+    return r.writeJSON(w)
+  case "csv":
+    return r.writeCSV(w, ',')
+  case "tsv":
+    return r.writeCSV(w, '\t')
+  }
+  return r.writeText(w)
+}
+
+func (r *report) writeText(w io.Writer) error {
+  for _, f := range r.fields {
+    fmt.Fprintf(w, "%s: %s", f.title, f.format(formatNumber))
+    if f.estimate {
+      fmt.Fprintf(w, " [%s, %s]",
+        formatNumber(f.lo, false), formatNumber(f.hi, false))
+    }
+    fmt.Fprintln(w)
+  }
+
+  for _, t := range r.tables {
+    fmt.Fprintf(w, "%s:\n", t.title)
+    tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintf(tw, "%s\t\n", strings.Join(t.header(), "\t"))
+    for i := range t.rows {
+      fmt.Fprintf(tw, "%s\t\n", strings.Join(t.cells(i, formatNumber), "\t"))
+    }
+    if err := tw.Flush(); err != nil {
+      return err
+    }
+  }
+
+  for _, chart := range r.charts {
+    if _, err := io.WriteString(w, chart); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+func (r *report) writeJSON(w io.Writer) error {
+  var members []string
+  member := func(key, value string) {
+    members = append(members, jsonString(key)+": "+value)
+  }
+
+  for _, f := range r.fields {
+    member(f.key, f.format(jsonNumber))
+    if f.estimate {
+      member(f.key+"_lower", jsonNumber(f.lo, false))
+      member(f.key+"_upper", jsonNumber(f.hi, false))
+    }
+  }
+  for _, t := range r.tables {
+    var rows []string
+    header := t.header()
+    for i := range t.rows {
+      cells := t.cells(i, jsonNumber)
+      for j := range cells {
+        if j == 0 && t.label != "" {
+          cells[j] = jsonString(cells[j])
+        }
+        cells[j] = jsonString(header[j]) + ": " + cells[j]
+      }
+      rows = append(rows, "{"+strings.Join(cells, ", ")+"}")
+    }
+    if len(rows) == 0 {
+      member(t.key, "[]")
+      continue
+    }
+    member(t.key, "[\n    "+strings.Join(rows, ",\n    ")+"\n  ]")
+  }
+
+  _, err := fmt.Fprintf(w, "{\n  %s\n}\n", strings.Join(members, ",\n  "))
+  return err
+}
+
+func (r *report) writeCSV(w io.Writer, comma rune) error {
+  cw := csv.NewWriter(w)
+  cw.Comma = comma
+
+  var header, record []string
+  for _, f := range r.fields {
+    header = append(header, f.key)
+    record = append(record, f.format(formatNumber))
+    if f.estimate {
+      header = append(header, f.key+"_lower", f.key+"_upper")
+      record = append(record,
+        formatNumber(f.lo, false), formatNumber(f.hi, false))
+    }
+  }
+  if len(header) != 0 {
+    cw.Write(header)
+    cw.Write(record)
+  }
+
+  // Every table follows as a separate block after an empty line. Its
+  // first column holds the key of the table.
+  for i, t := range r.tables {
+    if len(header) != 0 || i != 0 {
+      cw.Flush()
+      fmt.Fprintln(w)
+    }
+    cw.Write(append([]string{"table"}, t.header()...))
+    for i := range t.rows {
+      cw.Write(append([]string{t.key}, t.cells(i, formatNumber)...))
+    }
+  }
+
+  cw.Flush()
+  return cw.Error()
+}
+
+// formatNumber formats x with the precision set by -precision,
+// or exactly.
+func formatNumber(x float64, exact bool) string {
+  if exact {
+    return strconv.FormatFloat(x, 'f', -1, 64)
+  }
+  return strconv.FormatFloat(x, 'f', precisionFlag, 64)
+}
+
+// jsonNumber formats x like formatNumber, NaN and infinities as null.
+func jsonNumber(x float64, exact bool) string {
+  if math.IsNaN(x) || math.IsInf(x, 0) {
+    return "null"
+  }
+  return formatNumber(x, exact)
+}
+
+// print writes the report to the standard output and returns the exit
+// status. It also saves the report as a baseline or compares it to one
+// if requested.
+func (r *report) print() int {
+  var regressed bool
+  if compareFlag != "" {
+    var err error
+    if regressed, err = r.compareBaseline(compareFlag); err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return exitFailure
+    }
+  }
+  if saveBaselineFlag != "" {
+    if err := r.saveBaseline(saveBaselineFlag); err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return exitFailure
+    }
+  }
+
+  if err := r.write(os.Stdout); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    return exitFailure
+  }
+  if regressed {
+    return exitRegression
+  }
+  return exitOK
+}
+
+func jsonString(s string) string {
+  b, _ := json.Marshal(s)
+  return string(b)
+}
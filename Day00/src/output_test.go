@@ -0,0 +1,94 @@
+package main
+
+import (
+  "anscombe/stats"
+  "bytes"
+  "encoding/csv"
+  "encoding/json"
+  "math"
+  "strings"
+  "testing"
+)
+
+// testReport returns a report with fields of every kind and two tables
+// of the same columns.
+func testReport() *report {
+  r := &report{fields: []field{
+    {key: "mean", title: "Mean", value: 1.0 / 3},
+    {key: "count", title: "Count", value: 3, exact: true},
+    {key: "skewness", title: "Skewness", value: math.NaN()},
+    {key: "median", title: "Median", value: 1, estimate: true, lo: 0.5, hi: 2},
+    {key: "p", title: "p-value", value: 1.5e-7, significant: true},
+    {key: "sum", title: "Sum", value: 1, text: "1.0000"},
+  }}
+  sample := stats.NewSample([]float64{1, 1, 2})
+  r.addFrequencies("modes", "Modes", sample.Modes())
+  r.addFrequencies("frequencies", "Frequencies", sample.Frequencies())
+  return r
+}
+
+func TestWriteCSV(t *testing.T) {
+  var buf bytes.Buffer
+  if err := testReport().writeCSV(&buf, ','); err != nil {
+    t.Fatal(err)
+  }
+
+  blocks := strings.Split(buf.String(), "\n\n")
+  if len(blocks) != 3 {
+    t.Fatalf("got %d blocks, want 3:\n%s", len(blocks), buf.String())
+  }
+  var records [][][]string
+  for _, block := range blocks {
+    rs, err := csv.NewReader(strings.NewReader(block)).ReadAll()
+    if err != nil {
+      t.Fatalf("%v in %q", err, block)
+    }
+    records = append(records, rs)
+  }
+
+  fields := records[0]
+  want := [][]string{
+    {"mean", "count", "skewness", "median", "median_lower", "median_upper", "p", "sum"},
+    {"0.33", "3", "NaN", "1.00", "0.50", "2.00", "1.5e-07", "1.0000"},
+  }
+  if strings.Join(fields[0], ",") != strings.Join(want[0], ",") ||
+    strings.Join(fields[1], ",") != strings.Join(want[1], ",") {
+    t.Errorf("fields = %q, want %q", fields, want)
+  }
+
+  for i, key := range []string{"modes", "frequencies"} {
+    table := records[i+1]
+    if table[0][0] != "table" || table[0][1] != "value" {
+      t.Errorf("%s: header %q", key, table[0])
+    }
+    for _, row := range table[1:] {
+      if row[0] != key {
+        t.Errorf("%s: row %q", key, row)
+      }
+    }
+  }
+}
+
+func TestWriteJSON(t *testing.T) {
+  var buf bytes.Buffer
+  if err := testReport().writeJSON(&buf); err != nil {
+    t.Fatal(err)
+  }
+
+  var got map[string]any
+  if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+    t.Fatalf("%v in\n%s", err, buf.String())
+  }
+  for key, want := range map[string]any{
+    "mean": 0.33, "count": 3.0, "skewness": nil, "median": 1.0,
+    "median_lower": 0.5, "median_upper": 2.0, "p": 1.5e-7, "sum": 1.0,
+  } {
+    if got[key] != want {
+      t.Errorf("%s = %v, want %v", key, got[key], want)
+    }
+  }
+  if freq, ok := got["frequencies"].([]any); !ok || len(freq) != 2 ||
+    freq[1].(map[string]any)["value"] != 2.0 {
+    t.Errorf("frequencies = %v", got["frequencies"])
+  }
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+  "anscombe/stats"
+  "fmt"
+  "math"
+  "os"
+)
+
+// pairs describes the relation between the columns of x,y input.
+// Datasets of Anscombe's quartet share the summary statistics but not
+// the rank correlation and residuals, so these are printed as well.
+func pairs(reader *stats.Reader, meanFlag, deviationFlag bool) int {
+  xs, ys, err := reader.ReadPairs(os.Stdin)
+  if err == nil && len(xs) < 3 {
+    err = fmt.Errorf("%d valid pairs", len(xs))
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected at least three x,y pairs of numbers, separated by newlines\n",
+      err)
+    return exitInvalidInput
+  }
+
+  var r report
+  r.fields = append(r.fields,
+    field{key: "pairs", title: "Pairs", value: float64(len(xs)), exact: true})
+  if meanFlag {
+    r.add("mean_x", "Mean X", stats.Mean(xs))
+    r.add("mean_y", "Mean Y", stats.Mean(ys))
+  }
+  if deviationFlag {
+    sdX, sdY := &metric{label: "SD X", ddof: true}, &metric{label: "SD Y", ddof: true}
+    r.add("deviation_x", sdX.title(), math.Sqrt(stats.NewSample(xs).Variance(ddofFlag)))
+    r.add("deviation_y", sdY.title(), math.Sqrt(stats.NewSample(ys).Variance(ddofFlag)))
+  }
+
+  pearson, spearman := stats.Pearson(xs, ys), stats.Spearman(xs, ys)
+  r.add("covariance", "Covariance", stats.Covariance(xs, ys))
+  r.add("pearson", "Pearson", pearson)
+  r.add("spearman", "Spearman", spearman)
+
+  line := stats.LinearRegression(xs, ys)
+  r.add("slope", "Slope", line.Slope)
+  r.add("intercept", "Intercept", line.Intercept)
+  r.add("r_squared", "R²", line.RSquared)
+
+  residuals := stats.NewSample(line.Residuals(xs, ys))
+  stderr := line.StdErr(xs, ys)
+  r.add("residual_se", "Residual SE", stderr)
+  r.add("residual_min", "Residual min", residuals.Min())
+  r.add("residual_median", "Residual median", residuals.Median())
+  r.add("residual_max", "Residual max", residuals.Max())
+
+  if math.Abs(pearson-spearman) > 0.1 {
+    fmt.Fprintln(os.Stderr, "Warning: Pearson and Spearman correlations"+
+      " differ, the relation is not linear or is driven by outliers")
+  }
+  if worst := math.Max(-residuals.Min(), residuals.Max()); worst > 3*stderr {
+    fmt.Fprintf(os.Stderr,
+      "Warning: a residual of %.2f exceeds 3 standard errors\n", worst)
+  }
+
+  if plotFlag != "" {
+    var s svg
+    s.scatter(xs, ys, line)
+    if status := writePlot(&s); status != exitOK {
+      return status
+    }
+  }
+  return r.print()
+}
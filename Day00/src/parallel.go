@@ -0,0 +1,67 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "math"
+  "os"
+)
+
+var (
+  parallelFlag bool
+  jobsFlag     int
+)
+
+func init() {
+  flag.BoolVar(&parallelFlag, "parallel", false,
+    "A bool. Parse the input in parallel chunks and compute the quantiles\n"+
+      "by selection instead of sorting\n"+
+      "(mode, trimmed and winsorized means are not available)")
+  flag.IntVar(&jobsFlag, "jobs", 0,
+    "An int. Number of goroutines parsing the input in parallel mode,\n"+
+      "0 for one per CPU")
+}
+
+// parallel computes the statistics of large input quickly: chunks of
+// it are parsed concurrently, the moments of the chunks are merged and
+// the quantiles are selected without sorting the numbers.
+func parallel(reader *stats.Reader) int {
+  nums, moments, err := reader.ReadChunked(os.Stdin, jobsFlag)
+  if err == nil && len(nums) == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintln(os.Stderr, errmsg(err, reader.Range))
+    return exitInvalidInput
+  }
+
+  fractions := quantiles()
+  var r report
+  for _, m := range selected() {
+    var x float64
+    if f, ok := streamed[m.name]; ok {
+      x = f(moments)
+    } else if p, ok := fractions[m.name]; ok {
+      x = stats.QuantileSelect(nums, p)
+    } else if m.name == "iqr" {
+      x = stats.QuantileSelect(nums, 0.75) - stats.QuantileSelect(nums, 0.25)
+    } else if m.name == "mad" {
+      x = mad(nums)
+    } else {
+      continue
+    }
+    r.fields = append(r.fields, m.field(x))
+  }
+  return r.print()
+}
+
+// mad returns the median absolute deviation of nums by selection.
+func mad(nums []float64) float64 {
+  med := stats.MedianSelect(nums)
+  dev := make([]float64, len(nums))
+  for i, x := range nums {
+    dev[i] = math.Abs(x - med)
+  }
+  return stats.MedianSelect(dev)
+}
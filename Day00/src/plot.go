@@ -0,0 +1,189 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "math"
+  "strings"
+)
+
+// maxBins limits the number of histogram bins.
+const maxBins int = 1000
+
+var (
+  histFlag     bool
+  binsFlag     int
+  binWidthFlag float64
+  boxFlag      bool
+  widthFlag    int
+  asciiFlag    bool
+)
+
+func init() {
+  flag.BoolVar(&histFlag, "hist", false,
+    "A bool. Draw the histogram")
+  flag.IntVar(&binsFlag, "bins", 0,
+    "An int. Number of histogram bins\n"+
+      "(default by Freedman-Diaconis or Sturges rule)")
+  flag.Float64Var(&binWidthFlag, "bin-width", 0,
+    "A float. Width of histogram bins, instead of -bins")
+  flag.BoolVar(&boxFlag, "box", false,
+    "A bool. Draw the box plot")
+  flag.IntVar(&widthFlag, "width", 60,
+    "An int. Width of the plots in characters")
+  flag.BoolVar(&asciiFlag, "ascii", false,
+    "A bool. Draw the plots with ASCII characters only")
+}
+
+// histogram returns the bins selected by -bins or -bin-width. By default
+// the width is chosen by Freedman-Diaconis rule, or the number of bins
+// by Sturges' rule if the interquartile range is zero.
+func histogram(s *stats.Sample) ([]stats.Bin, error) {
+  width := binWidthFlag
+  if binsFlag == 0 && width == 0 {
+    width = s.FreedmanDiaconisWidth()
+    if width == 0 || !(s.Range()/width < float64(maxBins)) {
+      return s.Histogram(stats.SturgesBins(s.Len())), nil
+    }
+  }
+
+  if width != 0 {
+    if !(s.Range()/width < float64(maxBins)) {
+      return nil, fmt.Errorf("-bin-width makes more than %d bins", maxBins)
+    }
+    return s.HistogramWidth(width), nil
+  }
+  return s.Histogram(binsFlag), nil
+}
+
+// addHistogram adds the histogram to the report, as a chart in the text
+// format and as a table of bins otherwise.
+func (r *report) addHistogram(bins []stats.Bin) {
+  t := table{key: "histogram", title: "Histogram", columns: []column{
+    {"lo", false}, {"hi", false}, {"count", true},
+  }}
+  for _, b := range bins {
+    t.rows = append(t.rows, []float64{b.Lo, b.Hi, float64(b.Count)})
+  }
+
+  if formatFlag != "text" {
+    r.tables = append(r.tables, t)
+    return
+  }
+
+  peak := 0
+  for _, b := range bins {
+    peak = max(peak, b.Count)
+  }
+
+  var sb strings.Builder
+  sb.WriteString("Histogram:\n")
+  for i, b := range bins {
+    closing := ")"
+    if i == len(bins)-1 {
+      closing = "]"
+    }
+    interval := fmt.Sprintf("[%s, %s%s", formatNumber(b.Lo, false),
+      formatNumber(b.Hi, false), closing)
+    fmt.Fprintf(&sb, "%-*s %s %d\n", intervalWidth(bins), interval,
+      bar(float64(b.Count)/float64(peak)*float64(widthFlag)), b.Count)
+  }
+  r.charts = append(r.charts, sb.String())
+}
+
+func intervalWidth(bins []stats.Bin) int {
+  n := 0
+  for _, b := range bins {
+    n = max(n, len(formatNumber(b.Lo, false))+len(formatNumber(b.Hi, false))+4)
+  }
+  return n
+}
+
+// fraction returns the position of x in the interval [lo, hi] as
+// a fraction of its length. The ends are halved first, so that the
+// length does not overflow for a range of extreme values.
+func fraction(x, lo, hi float64) float64 {
+  return (x/2 - lo/2) / (hi/2 - lo/2)
+}
+
+// bar returns a bar of the given length in characters, drawn with
+// eighths of a block unless -ascii is set.
+func bar(length float64) string {
+  if asciiFlag {
+    return strings.Repeat("#", int(math.Round(length)))
+  }
+
+  eighths := int(math.Round(length * 8))
+  b := strings.Repeat("█", eighths/8)
+  if eighths%8 != 0 {
+    b += string([]rune("▏▎▍▌▋▊▉")[eighths%8-1])
+  }
+  return b
+}
+
+// addBoxPlot adds the box plot to the report, as a chart in the text
+// format and as fields otherwise.
+func (r *report) addBoxPlot(s *stats.Sample) {
+  b := s.BoxPlot()
+  if formatFlag != "text" {
+    r.add("box_lower", "Lower whisker", b.Lower)
+    r.add("box_q1", "Q1", b.Q1)
+    r.add("box_median", "Median", b.Median)
+    r.add("box_q3", "Q3", b.Q3)
+    r.add("box_upper", "Upper whisker", b.Upper)
+
+    t := table{key: "box_outliers", title: "Box plot outliers",
+      columns: []column{{"value", true}}}
+    for _, x := range b.Outliers {
+      t.rows = append(t.rows, []float64{x})
+    }
+    r.tables = append(r.tables, t)
+    return
+  }
+
+  whisker, box, median, outlier := '─', '▒', '┃', '•'
+  left, right := '├', '┤'
+  if asciiFlag {
+    whisker, box, median, outlier = '-', '=', '|', 'o'
+    left, right = '|', '|'
+  }
+
+  lo, hi := s.Min(), s.Max()
+  scale := func(x float64) int {
+    if hi == lo {
+      return widthFlag / 2
+    }
+    t := fraction(x, lo, hi)
+    if !(t > 0) {
+      return 0
+    }
+    return min(int(math.Round(t*float64(widthFlag-1))), widthFlag-1)
+  }
+
+  line := []rune(strings.Repeat(" ", widthFlag))
+  for i := scale(b.Lower); i <= scale(b.Upper); i++ {
+    line[i] = whisker
+  }
+  for i := scale(b.Q1); i <= scale(b.Q3); i++ {
+    line[i] = box
+  }
+  line[scale(b.Lower)], line[scale(b.Upper)] = left, right
+  if asciiFlag {
+    line[scale(b.Q1)], line[scale(b.Q3)] = '[', ']'
+  }
+  line[scale(b.Median)] = median
+  for _, x := range b.Outliers {
+    line[scale(x)] = outlier
+  }
+
+  minLabel, maxLabel := formatNumber(lo, true), formatNumber(hi, true)
+  gap := max(widthFlag-len(minLabel)-len(maxLabel), 1)
+  r.charts = append(r.charts, fmt.Sprintf(
+    "Box plot:\n%s\n%s%s%s\n"+
+      "whiskers %s..%s, box %s..%s, median %s, %d outliers\n",
+    string(line), minLabel, strings.Repeat(" ", gap), maxLabel,
+    formatNumber(b.Lower, false), formatNumber(b.Upper, false),
+    formatNumber(b.Q1, false), formatNumber(b.Q3, false),
+    formatNumber(b.Median, false), len(b.Outliers)))
+}
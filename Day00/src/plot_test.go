@@ -0,0 +1,44 @@
+package main
+
+import (
+  "anscombe/stats"
+  "strings"
+  "testing"
+)
+
+func TestPlots(t *testing.T) {
+  tests := []struct {
+    name string
+    xs   []float64
+  }{
+    {"extreme", []float64{-1e308, 1e308, 0}},
+    {"constant", []float64{3, 3, 3}},
+    {"single", []float64{7}},
+    {"outliers", []float64{-40, 1, 2, 3, 4, 5, 6, 7, 8, 9, 30}},
+  }
+
+  for _, tt := range tests {
+    s := stats.NewSample(tt.xs)
+    bins, err := histogram(s)
+    if err != nil {
+      t.Errorf("%s: histogram: %v", tt.name, err)
+      continue
+    }
+
+    var r report
+    r.addHistogram(bins)
+    r.addBoxPlot(s)
+    if len(r.charts) != 2 {
+      t.Errorf("%s: got %d charts, want 2", tt.name, len(r.charts))
+      continue
+    }
+    for _, chart := range r.charts {
+      if strings.Contains(chart, "NaN") || strings.Contains(chart, "Inf") {
+        t.Errorf("%s: chart is not finite:\n%s", tt.name, chart)
+      }
+    }
+    if box := strings.Split(r.charts[1], "\n")[1]; len([]rune(box)) != widthFlag {
+      t.Errorf("%s: box plot is %d wide, want %d", tt.name, len([]rune(box)), widthFlag)
+    }
+  }
+}
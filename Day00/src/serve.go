@@ -0,0 +1,288 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/csv"
+  "encoding/json"
+  "errors"
+  "flag"
+  "fmt"
+  "io"
+  "mime"
+  "net/http"
+  "os"
+  "sort"
+  "strings"
+  "sync"
+  "time"
+)
+
+// maxBody limits the size of a request body in bytes.
+const maxBody int64 = 64 << 20
+
+// maxSeries limits the number of series kept by the server.
+const maxSeries int = 1000
+
+// Timeouts of the connections of the server.
+const (
+  readHeaderTimeout = 10 * time.Second
+  idleTimeout       = 2 * time.Minute
+)
+
+var serveFlag string
+
+func init() {
+  flag.StringVar(&serveFlag, "serve", "",
+    "A string. Serve the metrics over HTTP on the address, e.g. :8080:\n"+
+      "POST /stats returns the metrics of the numbers in the body,\n"+
+      "POST /series/NAME adds them to a named series,\n"+
+      "GET /series/NAME returns its running metrics")
+}
+
+// server computes the metrics of numbers sent over HTTP.
+type server struct {
+  rg        stats.Range
+  eps       float64
+  maxSeries int
+
+  mu     sync.Mutex
+  series map[string]*series
+}
+
+// series is a named stream of numbers described in constant memory.
+type series struct {
+  mu      sync.Mutex
+  moments stats.Moments
+  sketch  *stats.QuantileSketch
+}
+
+// serve serves the metrics on the address until it fails and returns
+// the exit status. The numbers are written in full unless -precision
+// is set.
+func serve(rg stats.Range, addr string, eps float64) int {
+  explicit := false
+  flag.Visit(func(f *flag.Flag) {
+    explicit = explicit || f.Name == "precision"
+  })
+  if !explicit {
+    precisionFlag = -1
+  }
+
+  s := &server{rg: rg, eps: eps, maxSeries: maxSeries,
+    series: make(map[string]*series)}
+  srv := &http.Server{
+    Addr:              addr,
+    Handler:           s.handler(),
+    ReadHeaderTimeout: readHeaderTimeout,
+    IdleTimeout:       idleTimeout,
+  }
+  fmt.Fprintf(os.Stderr, "Serving on %s\n", addr)
+  if err := srv.ListenAndServe(); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+  }
+  return exitFailure
+}
+
+// handler returns the handler of the endpoints of the server.
+func (s *server) handler() http.Handler {
+  mux := http.NewServeMux()
+  mux.HandleFunc("/stats", s.handleStats)
+  mux.HandleFunc("/series", s.handleSeriesList)
+  mux.HandleFunc("/series/", s.handleSeries)
+  return mux
+}
+
+// handleStats responds with the selected metrics of the numbers of the
+// request.
+func (s *server) handleStats(w http.ResponseWriter, req *http.Request) {
+  if req.Method != http.MethodPost {
+    httpError(w, http.StatusMethodNotAllowed, errors.New("expected POST"))
+    return
+  }
+  nums, err := s.readNumbers(req)
+  if err != nil {
+    httpError(w, http.StatusBadRequest, err)
+    return
+  }
+
+  sample := stats.NewSample(nums)
+  var r report
+  for _, m := range selected() {
+    r.fields = append(r.fields, m.field(m.value(sample)))
+  }
+  writeReport(w, &r)
+}
+
+// handleSeriesList responds with the names of the series.
+func (s *server) handleSeriesList(w http.ResponseWriter, req *http.Request) {
+  if req.Method != http.MethodGet {
+    httpError(w, http.StatusMethodNotAllowed, errors.New("expected GET"))
+    return
+  }
+  s.mu.Lock()
+  names := make([]string, 0, len(s.series))
+  for name := range s.series {
+    names = append(names, name)
+  }
+  s.mu.Unlock()
+
+  sort.Strings(names)
+  w.Header().Set("Content-Type", "application/json")
+  json.NewEncoder(w).Encode(names)
+}
+
+// handleSeries adds the numbers of a POST request to the series named
+// by the path, creating it if needed, responds to GET with its running
+// metrics and deletes it on DELETE.
+func (s *server) handleSeries(w http.ResponseWriter, req *http.Request) {
+  name := strings.TrimPrefix(req.URL.Path, "/series/")
+  if name == "" || strings.Contains(name, "/") {
+    httpError(w, http.StatusNotFound, errors.New("expected /series/NAME"))
+    return
+  }
+
+  switch req.Method {
+  case http.MethodPost:
+    // The series is only created once the numbers are valid.
+    nums, err := s.readNumbers(req)
+    if err != nil {
+      httpError(w, http.StatusBadRequest, err)
+      return
+    }
+    s.mu.Lock()
+    sr := s.series[name]
+    if sr == nil && len(s.series) >= s.maxSeries {
+      s.mu.Unlock()
+      httpError(w, http.StatusInsufficientStorage,
+        fmt.Errorf("too many series, at most %d", s.maxSeries))
+      return
+    }
+    if sr == nil {
+      sr = &series{sketch: stats.NewQuantileSketch(s.eps)}
+      s.series[name] = sr
+    }
+    sr.mu.Lock()
+    s.mu.Unlock()
+    for _, num := range nums {
+      sr.moments.Add(num)
+      sr.sketch.Add(num)
+    }
+    r := streamReport(&sr.moments, sr.sketch)
+    sr.mu.Unlock()
+    writeReport(w, &r)
+  case http.MethodGet:
+    s.mu.Lock()
+    sr := s.series[name]
+    s.mu.Unlock()
+    if sr == nil {
+      httpError(w, http.StatusNotFound, fmt.Errorf("no series %q", name))
+      return
+    }
+    sr.mu.Lock()
+    r := streamReport(&sr.moments, sr.sketch)
+    sr.mu.Unlock()
+    writeReport(w, &r)
+  case http.MethodDelete:
+    s.mu.Lock()
+    sr := s.series[name]
+    delete(s.series, name)
+    s.mu.Unlock()
+    if sr == nil {
+      httpError(w, http.StatusNotFound, fmt.Errorf("no series %q", name))
+      return
+    }
+    w.WriteHeader(http.StatusNoContent)
+  default:
+    httpError(w, http.StatusMethodNotAllowed,
+      errors.New("expected GET, POST or DELETE"))
+  }
+}
+
+// readNumbers reads the numbers of the request body: a JSON array for
+// application/json, comma separated values for text/csv or a file
+// uploaded as multipart/form-data, and lines of numbers otherwise.
+func (s *server) readNumbers(req *http.Request) ([]float64, error) {
+  body := http.MaxBytesReader(nil, req.Body, maxBody)
+  reader := &stats.Reader{Range: s.rg}
+
+  var nums []float64
+  var err error
+  mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+  switch mediaType {
+  case "application/json":
+    if err = json.NewDecoder(body).Decode(&nums); err == nil {
+      for _, num := range nums {
+        if !s.rg.Contains(num) {
+          return nil, fmt.Errorf("%v: %w", num, stats.ErrRange)
+        }
+      }
+    }
+  case "text/csv":
+    nums, err = s.readCSV(body)
+  case "multipart/form-data":
+    req.Body = body
+    file, _, ferr := req.FormFile("file")
+    if ferr != nil {
+      return nil, ferr
+    }
+    defer file.Close()
+    nums, err = s.readCSV(file)
+  default:
+    nums, err = reader.ReadFloats(body)
+  }
+  if err == nil && len(nums) == 0 {
+    err = errNoData
+  }
+  return nums, err
+}
+
+// readCSV reads the numbers of every field of comma separated values.
+// The first record is skipped as a header if it has no numbers.
+func (s *server) readCSV(r io.Reader) ([]float64, error) {
+  cr := csv.NewReader(r)
+  cr.FieldsPerRecord = -1
+  cr.TrimLeadingSpace = true
+
+  var nums []float64
+  for first := true; ; first = false {
+    record, err := cr.Read()
+    if err == io.EOF {
+      return nums, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    line, _ := cr.FieldPos(0)
+
+    var row []float64
+    var rowErr error
+    for _, f := range record {
+      num, err := stats.ParseFloat(strings.TrimSpace(f), s.rg)
+      if err != nil {
+        rowErr = &stats.ParseError{Line: line, Text: f, Err: err}
+        continue
+      }
+      row = append(row, num)
+    }
+    if first && len(row) == 0 {
+      continue
+    }
+    if rowErr != nil {
+      return nil, rowErr
+    }
+    nums = append(nums, row...)
+  }
+}
+
+// writeReport writes the report as the JSON response.
+func writeReport(w http.ResponseWriter, r *report) {
+  w.Header().Set("Content-Type", "application/json")
+  r.writeJSON(w)
+}
+
+// httpError writes the error as a JSON response with the status code.
+func httpError(w http.ResponseWriter, code int, err error) {
+  w.Header().Set("Content-Type", "application/json")
+  w.WriteHeader(code)
+  fmt.Fprintf(w, "{\"error\": %s}\n", jsonString(err.Error()))
+}
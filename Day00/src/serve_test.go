@@ -0,0 +1,166 @@
+package main
+
+import (
+  "anscombe/stats"
+  "bytes"
+  "encoding/json"
+  "mime/multipart"
+  "net/http"
+  "net/http/httptest"
+  "slices"
+  "strings"
+  "testing"
+)
+
+// request sends the request to the handler and returns the status code
+// and the decoded JSON response.
+func request(t *testing.T, h http.Handler, method, path, contentType, body string) (int, any) {
+  t.Helper()
+  req := httptest.NewRequest(method, path, strings.NewReader(body))
+  if contentType != "" {
+    req.Header.Set("Content-Type", contentType)
+  }
+  w := httptest.NewRecorder()
+  h.ServeHTTP(w, req)
+
+  var resp any
+  if w.Body.Len() != 0 {
+    if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+      t.Fatalf("%s %s: %v in %q", method, path, err, w.Body.String())
+    }
+  }
+  return w.Code, resp
+}
+
+func newTestServer() *server {
+  return &server{rg: stats.Range{Min: -100, Max: 100}, eps: 0.01,
+    maxSeries: 3, series: make(map[string]*series)}
+}
+
+func TestServeStats(t *testing.T) {
+  var form bytes.Buffer
+  mw := multipart.NewWriter(&form)
+  fw, _ := mw.CreateFormFile("file", "data.csv")
+  fw.Write([]byte("x,y\n1,2\n3,4\n"))
+  mw.Close()
+
+  tests := []struct {
+    name, contentType, body string
+    code                    int
+    mean                    float64
+  }{
+    {"text", "text/plain", "1\n2\n6\n", http.StatusOK, 3},
+    {"no type", "", "1\n2\n6\n", http.StatusOK, 3},
+    {"json", "application/json", "[1, 2, 6]", http.StatusOK, 3},
+    {"csv", "text/csv", "a,b\n1,2\n3,4\n", http.StatusOK, 2.5},
+    {"multipart", mw.FormDataContentType(), form.String(), http.StatusOK, 2.5},
+    {"not a number", "text/plain", "1\nx\n", http.StatusBadRequest, 0},
+    {"out of range", "application/json", "[1, 1000]", http.StatusBadRequest, 0},
+    {"bad json", "application/json", "[1,", http.StatusBadRequest, 0},
+    {"bad csv", "text/csv", "1,2\n3,x\n", http.StatusBadRequest, 0},
+    {"empty", "text/plain", "", http.StatusBadRequest, 0},
+  }
+
+  h := newTestServer().handler()
+  for _, tt := range tests {
+    code, resp := request(t, h, http.MethodPost, "/stats", tt.contentType, tt.body)
+    if code != tt.code {
+      t.Errorf("%s: status %d, want %d: %v", tt.name, code, tt.code, resp)
+      continue
+    }
+    body, _ := resp.(map[string]any)
+    if code != http.StatusOK {
+      if _, ok := body["error"].(string); !ok {
+        t.Errorf("%s: got %v, want an error", tt.name, resp)
+      }
+      continue
+    }
+    if body["mean"] != tt.mean {
+      t.Errorf("%s: mean %v, want %v", tt.name, body["mean"], tt.mean)
+    }
+  }
+
+  if code, _ := request(t, h, http.MethodGet, "/stats", "", ""); code != http.StatusMethodNotAllowed {
+    t.Errorf("GET /stats: status %d, want %d", code, http.StatusMethodNotAllowed)
+  }
+}
+
+func TestServeSeries(t *testing.T) {
+  h := newTestServer().handler()
+  steps := []struct {
+    method, path, body string
+    code               int
+    mean               float64
+  }{
+    {http.MethodGet, "/series/foo", "", http.StatusNotFound, 0},
+    {http.MethodPost, "/series/foo", "x", http.StatusBadRequest, 0},
+    {http.MethodGet, "/series/foo", "", http.StatusNotFound, 0},
+    {http.MethodPost, "/series/foo", "1\n2\n", http.StatusOK, 1.5},
+    {http.MethodPost, "/series/foo", "6\n", http.StatusOK, 3},
+    {http.MethodPost, "/series/foo", "1000\n", http.StatusBadRequest, 0},
+    {http.MethodGet, "/series/foo", "", http.StatusOK, 3},
+    {http.MethodPut, "/series/foo", "1\n", http.StatusMethodNotAllowed, 0},
+    {http.MethodGet, "/series/", "", http.StatusNotFound, 0},
+    {http.MethodGet, "/series/foo/bar", "", http.StatusNotFound, 0},
+    {http.MethodDelete, "/series/foo", "", http.StatusNoContent, 0},
+    {http.MethodDelete, "/series/foo", "", http.StatusNotFound, 0},
+    {http.MethodGet, "/series/foo", "", http.StatusNotFound, 0},
+  }
+
+  for _, step := range steps {
+    code, resp := request(t, h, step.method, step.path, "text/plain", step.body)
+    if code != step.code {
+      t.Fatalf("%s %s %q: status %d, want %d: %v",
+        step.method, step.path, step.body, code, step.code, resp)
+    }
+    if code == http.StatusOK && resp.(map[string]any)["mean"] != step.mean {
+      t.Errorf("%s %s %q: mean %v, want %v",
+        step.method, step.path, step.body, resp.(map[string]any)["mean"], step.mean)
+    }
+    if step.method == http.MethodPost && step.body == "x" {
+      _, names := request(t, h, http.MethodGet, "/series", "", "")
+      if len(names.([]any)) != 0 {
+        t.Errorf("invalid POST created the series: %v", names)
+      }
+    }
+  }
+}
+
+func TestServeSeriesList(t *testing.T) {
+  h := newTestServer().handler()
+  for _, name := range []string{"b", "a", "c"} {
+    request(t, h, http.MethodPost, "/series/"+name, "", "1\n")
+  }
+
+  code, resp := request(t, h, http.MethodGet, "/series", "", "")
+  var names []string
+  for _, name := range resp.([]any) {
+    names = append(names, name.(string))
+  }
+  if code != http.StatusOK || !slices.Equal(names, []string{"a", "b", "c"}) {
+    t.Errorf("GET /series: status %d, names %v", code, names)
+  }
+  if code, _ := request(t, h, http.MethodPost, "/series", "", ""); code != http.StatusMethodNotAllowed {
+    t.Errorf("POST /series: status %d, want %d", code, http.StatusMethodNotAllowed)
+  }
+}
+
+func TestServeSeriesLimit(t *testing.T) {
+  h := newTestServer().handler()
+  for _, name := range []string{"a", "b", "c"} {
+    if code, resp := request(t, h, http.MethodPost, "/series/"+name, "", "1\n"); code != http.StatusOK {
+      t.Fatalf("POST /series/%s: status %d: %v", name, code, resp)
+    }
+  }
+
+  if code, _ := request(t, h, http.MethodPost, "/series/d", "", "1\n"); code != http.StatusInsufficientStorage {
+    t.Errorf("POST beyond the limit: status %d, want %d", code, http.StatusInsufficientStorage)
+  }
+  if code, _ := request(t, h, http.MethodPost, "/series/a", "", "2\n"); code != http.StatusOK {
+    t.Errorf("POST to an existing series at the limit: status %d", code)
+  }
+  request(t, h, http.MethodDelete, "/series/b", "", "")
+  if code, _ := request(t, h, http.MethodPost, "/series/d", "", "1\n"); code != http.StatusOK {
+    t.Errorf("POST after a DELETE: status %d", code)
+  }
+}
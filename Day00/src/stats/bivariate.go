@@ -0,0 +1,130 @@
+package stats
+
+import (
+  "math"
+  "sort"
+)
+
+// Covariance returns the population covariance of xs and ys.
+// It panics if the slices have different lengths.
+func Covariance[T Number](xs, ys []T) float64 {
+  return covariance(toPairs(xs, ys))
+}
+
+// Pearson returns the Pearson product-moment correlation coefficient
+// of xs and ys. It panics if the slices have different lengths.
+func Pearson[T Number](xs, ys []T) float64 {
+  return pearson(toPairs(xs, ys))
+}
+
+// Spearman returns the Spearman rank correlation coefficient of xs and
+// ys, that is the Pearson correlation of their ranks. Tied values get
+// the average of their ranks. It panics if the slices have different
+// lengths.
+func Spearman[T Number](xs, ys []T) float64 {
+  fx, fy := toPairs(xs, ys)
+  return pearson(ranks(fx), ranks(fy))
+}
+
+// Regression is the least-squares line y = Slope*x + Intercept.
+type Regression struct {
+  Slope, Intercept float64
+  // RSquared is the coefficient of determination.
+  RSquared float64
+}
+
+// LinearRegression fits a line to the points (xs[i], ys[i]) by the
+// ordinary least squares. It panics if the slices have different
+// lengths.
+func LinearRegression[T Number](xs, ys []T) Regression {
+  fx, fy := toPairs(xs, ys)
+
+  slope := covariance(fx, fy) / variance(fx)
+  r := pearson(fx, fy)
+  return Regression{
+    Slope:     slope,
+    Intercept: mean(fy) - slope*mean(fx),
+    RSquared:  r * r,
+  }
+}
+
+// Predict returns the value of the line at x.
+func (r Regression) Predict(x float64) float64 {
+  return r.Slope*x + r.Intercept
+}
+
+// Residuals returns the differences between ys and the values
+// predicted at xs. It panics if the slices have different lengths.
+func (r Regression) Residuals(xs, ys []float64) []float64 {
+  fx, fy := toPairs(xs, ys)
+
+  res := make([]float64, len(fx))
+  for i := range fx {
+    res[i] = fy[i] - r.Predict(fx[i])
+  }
+  return res
+}
+
+// StdErr returns the standard error of the regression, the square root
+// of the residual sum of squares divided by n-2 degrees of freedom.
+func (r Regression) StdErr(xs, ys []float64) float64 {
+  res := r.Residuals(xs, ys)
+  if len(res) < 3 {
+    return math.NaN()
+  }
+
+  var sse float64
+  for _, e := range res {
+    sse += e * e
+  }
+  return math.Sqrt(sse / float64(len(res)-2))
+}
+
+func toPairs[T Number](xs, ys []T) ([]float64, []float64) {
+  if len(xs) != len(ys) {
+    panic("stats: slices have different lengths")
+  }
+  return toFloats(xs), toFloats(ys)
+}
+
+func covariance(xs, ys []float64) float64 {
+  if len(xs) == 0 {
+    return math.NaN()
+  }
+
+  mx, my := mean(xs), mean(ys)
+  var acc accumulator
+  for i := range xs {
+    acc.Add((xs[i] - mx) * (ys[i] - my))
+  }
+  return acc.Sum() / float64(len(xs))
+}
+
+func pearson(xs, ys []float64) float64 {
+  return covariance(xs, ys) / math.Sqrt(variance(xs)*variance(ys))
+}
+
+// ranks returns the 1-based ranks of xs, averaged over ties.
+func ranks(xs []float64) []float64 {
+  idx := make([]int, len(xs))
+  for i := range idx {
+    idx[i] = i
+  }
+  sort.SliceStable(idx, func(i, j int) bool {
+    return xs[idx[i]] < xs[idx[j]]
+  })
+
+  r := make([]float64, len(xs))
+  for i := 0; i < len(idx); {
+    j := i + 1
+    for j < len(idx) && xs[idx[j]] == xs[idx[i]] {
+      j++
+    }
+    avg := float64(i+j+1) / 2.0
+    for k := i; k < j; k++ {
+      r[idx[k]] = avg
+    }
+    i = j
+  }
+  return r
+}
@@ -0,0 +1,81 @@
+package stats
+
+import (
+  "math"
+  "testing"
+)
+
+// Anscombe's quartet: different datasets with the same summary statistics.
+var quartetX = []float64{10, 8, 13, 9, 11, 14, 6, 4, 12, 7, 5}
+var quartetX4 = []float64{8, 8, 8, 8, 8, 8, 8, 19, 8, 8, 8}
+var quartet = []struct {
+  name   string
+  xs, ys []float64
+}{
+  {"I", quartetX, []float64{8.04, 6.95, 7.58, 8.81, 8.33, 9.96, 7.24, 4.26, 10.84, 4.82, 5.68}},
+  {"II", quartetX, []float64{9.14, 8.14, 8.74, 8.77, 9.26, 8.10, 6.13, 3.10, 9.13, 7.26, 4.74}},
+  {"III", quartetX, []float64{7.46, 6.77, 12.74, 7.11, 7.81, 8.84, 6.08, 5.39, 8.15, 6.42, 5.73}},
+  {"IV", quartetX4, []float64{6.58, 5.76, 7.71, 8.84, 8.47, 7.04, 5.25, 12.50, 5.56, 7.91, 6.89}},
+}
+
+func TestQuartet(t *testing.T) {
+  for _, tt := range quartet {
+    t.Run(tt.name, func(t *testing.T) {
+      line := LinearRegression(tt.xs, tt.ys)
+      for _, got := range []struct {
+        metric    string
+        got, want float64
+      }{
+        {"Mean(x)", Mean(tt.xs), 9},
+        {"Mean(y)", Mean(tt.ys), 7.50},
+        {"Pearson", Pearson(tt.xs, tt.ys), 0.816},
+        {"Slope", line.Slope, 0.500},
+        {"Intercept", line.Intercept, 3.00},
+        {"RSquared", line.RSquared, 0.67},
+      } {
+        if math.Abs(got.got-got.want) > 0.005 {
+          t.Errorf("%s = %v, want %v", got.metric, got.got, got.want)
+        }
+      }
+    })
+  }
+
+  // Rank correlation tells the datasets apart.
+  if s1, s3 := Spearman(quartet[0].xs, quartet[0].ys),
+    Spearman(quartet[2].xs, quartet[2].ys); s3 <= s1 {
+    t.Errorf("Spearman: III %v, want more than I %v", s3, s1)
+  }
+}
+
+func TestSpearman(t *testing.T) {
+  tests := []struct {
+    name   string
+    xs, ys []float64
+    want   float64
+  }{
+    {"monotonic", []float64{1, 2, 3, 4}, []float64{1, 8, 27, 64}, 1},
+    {"reversed", []float64{1, 2, 3}, []float64{3, 2, 1}, -1},
+    {"ties", []float64{1, 2, 2, 3}, []float64{1, 2, 3, 4}, 0.9486832980505138},
+  }
+
+  for _, tt := range tests {
+    if got := Spearman(tt.xs, tt.ys); !almostEqual(got, tt.want) {
+      t.Errorf("%s: Spearman = %v, want %v", tt.name, got, tt.want)
+    }
+  }
+}
+
+func TestRegressionResiduals(t *testing.T) {
+  xs := []float64{0, 1, 2, 3}
+  ys := []float64{1, 3, 5, 7}
+  line := LinearRegression(xs, ys)
+  if !almostEqual(line.Slope, 2) || !almostEqual(line.Intercept, 1) ||
+    !almostEqual(line.RSquared, 1) {
+    t.Errorf("LinearRegression = %+v, want y = 2x + 1", line)
+  }
+  for _, e := range line.Residuals(xs, ys) {
+    if math.Abs(e) > eps {
+      t.Errorf("residual %v, want 0", e)
+    }
+  }
+}
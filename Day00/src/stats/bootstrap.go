@@ -0,0 +1,156 @@
+package stats
+
+import (
+  "math"
+  "math/rand"
+  "runtime"
+  "slices"
+  "sort"
+  "sync"
+)
+
+// Statistic is a function of a sample, such as (*Sample).Mean.
+type Statistic func(s *Sample) float64
+
+// Bootstrap estimates the sampling distribution of statistics by
+// resampling the observations with replacement.
+type Bootstrap struct {
+  // Resamples is the number of samples drawn.
+  Resamples int
+  // Seed makes the resamples reproducible. The result does not depend
+  // on the number of workers.
+  Seed int64
+  // Workers is the number of goroutines drawing samples,
+  // GOMAXPROCS if zero.
+  Workers int
+}
+
+// resamplesPerSeed is the number of resamples drawn from a random
+// source seeded by Seed and the index of the batch of resamples.
+const resamplesPerSeed = 64
+
+// maxJackknifeGroups limits the number of leave-out samples used to
+// estimate the acceleration of BCa intervals. Larger samples are
+// divided into this many groups left out together.
+const maxJackknifeGroups = 500
+
+// Replicates returns the values of every statistic on each of
+// the resamples of xs, indexed by the statistic and then the resample.
+func (b Bootstrap) Replicates(xs []float64, stats ...Statistic) [][]float64 {
+  reps := make([][]float64, len(stats))
+  for i := range reps {
+    reps[i] = make([]float64, b.Resamples)
+  }
+
+  workers := b.Workers
+  if workers <= 0 {
+    workers = runtime.GOMAXPROCS(0)
+  }
+  batches := make(chan int)
+  var wg sync.WaitGroup
+  for w := 0; w < workers; w++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      resample := make([]float64, len(xs))
+      for batch := range batches {
+        rng := rand.New(rand.NewSource(b.Seed*1000003 + int64(batch)))
+        end := min((batch+1)*resamplesPerSeed, b.Resamples)
+        for r := batch * resamplesPerSeed; r < end; r++ {
+          for i := range resample {
+            resample[i] = xs[rng.Intn(len(xs))]
+          }
+          slices.Sort(resample)
+          s := &Sample{resample}
+          for i, stat := range stats {
+            reps[i][r] = stat(s)
+          }
+        }
+      }
+    }()
+  }
+  for batch := 0; batch*resamplesPerSeed < b.Resamples; batch++ {
+    batches <- batch
+  }
+  close(batches)
+  wg.Wait()
+
+  return reps
+}
+
+// PercentileInterval returns the confidence interval of the given
+// level, 0 < level < 1, between the quantiles of the replicates.
+func PercentileInterval(reps []float64, level float64) (lo, hi float64) {
+  s := NewSample(finite(reps))
+  alpha := (1 - level) / 2
+  return s.Quantile(alpha), s.Quantile(1 - alpha)
+}
+
+// BCaInterval returns the bias-corrected and accelerated confidence
+// interval of the statistic of xs of the given level, 0 < level < 1,
+// from its bootstrap replicates. It corrects the percentile interval
+// for the bias and the skewness of the replicates.
+func BCaInterval(xs []float64, stat Statistic, reps []float64, level float64) (lo, hi float64) {
+  estimate := stat(NewSample(xs))
+  s := NewSample(finite(reps))
+  if s.Len() == 0 || math.IsNaN(estimate) {
+    return math.NaN(), math.NaN()
+  }
+
+  below := sort.SearchFloat64s(s.xs, estimate)
+  z0 := NormalQuantile(float64(below) / float64(s.Len()))
+  if math.IsInf(z0, 0) {
+    // The estimate is beyond all the replicates: the bias cannot be
+    // corrected.
+    return PercentileInterval(reps, level)
+  }
+  a := acceleration(xs, stat)
+
+  adjust := func(z float64) float64 {
+    return NormalCDF(z0 + (z0+z)/(1-a*(z0+z)))
+  }
+  alpha := (1 - level) / 2
+  return s.Quantile(adjust(NormalQuantile(alpha))),
+    s.Quantile(adjust(NormalQuantile(1 - alpha)))
+}
+
+// acceleration estimates the acceleration of BCa intervals by
+// the jackknife: the skewness of the statistic over the samples with
+// one observation, or group of observations, left out.
+func acceleration(xs []float64, stat Statistic) float64 {
+  groups := min(len(xs), maxJackknifeGroups)
+  jack := make([]float64, groups)
+  rest := make([]float64, 0, len(xs))
+  for g := range jack {
+    rest = rest[:0]
+    for i, x := range xs {
+      if i%groups != g {
+        rest = append(rest, x)
+      }
+    }
+    jack[g] = stat(NewSample(rest))
+  }
+
+  avg := mean(jack)
+  var num, den float64
+  for _, j := range jack {
+    d := avg - j
+    num += d * d * d
+    den += d * d
+  }
+  if den == 0 {
+    return 0
+  }
+  return num / (6 * math.Pow(den, 1.5))
+}
+
+// finite returns the numbers of xs that are neither NaN nor infinite.
+func finite(xs []float64) []float64 {
+  var fs []float64
+  for _, x := range xs {
+    if !math.IsNaN(x) && !math.IsInf(x, 0) {
+      fs = append(fs, x)
+    }
+  }
+  return fs
+}
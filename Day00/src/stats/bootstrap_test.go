@@ -0,0 +1,52 @@
+package stats
+
+import (
+  "math"
+  "math/rand"
+  "slices"
+  "testing"
+)
+
+func TestNormalQuantile(t *testing.T) {
+  for _, p := range []float64{1e-10, 0.001, 0.025, 0.3, 0.5, 0.9, 0.975, 1 - 1e-9} {
+    if got := NormalCDF(NormalQuantile(p)); math.Abs(got-p) > 1e-12*math.Max(1, 1/p) {
+      t.Errorf("NormalCDF(NormalQuantile(%v)) = %v", p, got)
+    }
+  }
+  if got := NormalQuantile(0.975); math.Abs(got-1.959963984540054) > 1e-12 {
+    t.Errorf("NormalQuantile(0.975) = %v", got)
+  }
+}
+
+func TestBootstrap(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  xs := make([]float64, 400)
+  for i := range xs {
+    xs[i] = 10 + 2*rng.NormFloat64()
+  }
+  mean := Statistic((*Sample).Mean)
+  median := Statistic((*Sample).Median)
+
+  one := Bootstrap{Resamples: 1000, Seed: 7, Workers: 1}.Replicates(xs, mean, median)
+  many := Bootstrap{Resamples: 1000, Seed: 7, Workers: 8}.Replicates(xs, mean, median)
+  for i := range one {
+    if !slices.Equal(one[i], many[i]) {
+      t.Fatal("replicates depend on the number of workers")
+    }
+  }
+
+  // The standard error of the mean is 2/sqrt(400) = 0.1.
+  s := NewSample(xs)
+  lo, hi := PercentileInterval(one[0], 0.95)
+  if math.Abs(hi-lo-2*1.96*0.1) > 0.05 || lo > s.Mean() || hi < s.Mean() {
+    t.Errorf("PercentileInterval = [%v, %v], mean %v", lo, hi, s.Mean())
+  }
+  blo, bhi := BCaInterval(xs, mean, one[0], 0.95)
+  if math.Abs(blo-lo) > 0.03 || math.Abs(bhi-hi) > 0.03 {
+    t.Errorf("BCaInterval = [%v, %v], percentile [%v, %v]", blo, bhi, lo, hi)
+  }
+  mlo, mhi := BCaInterval(xs, median, one[1], 0.95)
+  if mlo > s.Median() || mhi < s.Median() {
+    t.Errorf("BCaInterval of median = [%v, %v], median %v", mlo, mhi, s.Median())
+  }
+}
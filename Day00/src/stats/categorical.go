@@ -0,0 +1,112 @@
+package stats
+
+import (
+  "math"
+  "slices"
+)
+
+// Category is a row of the frequency table of categorical data.
+type Category struct {
+  Value string
+  Count int
+  // Relative is Count divided by the number of observations.
+  Relative float64
+}
+
+// Categories returns the frequency table of the tokens, one row for
+// every distinct token, from the most frequent; tokens of equal counts
+// are in ascending order.
+func Categories(tokens []string) []Category {
+  counts := make(map[string]int)
+  for _, t := range tokens {
+    counts[t]++
+  }
+
+  table := make([]Category, 0, len(counts))
+  n := float64(len(tokens))
+  for value, count := range counts {
+    table = append(table, Category{value, count, float64(count) / n})
+  }
+  slices.SortFunc(table, func(a, b Category) int {
+    if a.Count != b.Count {
+      return b.Count - a.Count
+    }
+    switch {
+    case a.Value < b.Value:
+      return -1
+    case a.Value > b.Value:
+      return 1
+    }
+    return 0
+  })
+  return table
+}
+
+// Entropy returns the Shannon entropy in bits of the distribution given
+// by the counts of its categories.
+func Entropy(counts []int) float64 {
+  var n int
+  for _, c := range counts {
+    n += c
+  }
+  if n == 0 {
+    return math.NaN()
+  }
+
+  var acc accumulator
+  for _, c := range counts {
+    if c > 0 {
+      p := float64(c) / float64(n)
+      acc.Add(-p * math.Log2(p))
+    }
+  }
+  return acc.Sum()
+}
+
+// ChiSquare tests whether the observed counts of categories follow the
+// expected distribution, given by the relative frequencies of the
+// categories in any scale, or the uniform distribution if expected is
+// nil. The statistic is Pearson's chi-squared with one degree of
+// freedom less than the number of categories. Categories that are
+// neither expected nor observed are left out.
+func ChiSquare(observed []int, expected []float64) TestResult {
+  k := len(observed)
+  if expected == nil {
+    expected = make([]float64, k)
+    for i := range expected {
+      expected[i] = 1
+    }
+  }
+  if len(expected) != k {
+    panic("stats: observed and expected counts differ in length")
+  }
+
+  var n int
+  for i, c := range observed {
+    n += c
+    if c == 0 && expected[i] == 0 {
+      k--
+    }
+  }
+  total := sum(expected)
+  if k < 2 || n == 0 || !(total > 0) {
+    return TestResult{Statistic: math.NaN(), DF: float64(k - 1), P: math.NaN()}
+  }
+
+  var acc accumulator
+  for i, c := range observed {
+    if expected[i] == 0 {
+      if c == 0 {
+        continue
+      }
+      // An observed category that is never expected rejects
+      // the distribution.
+      return TestResult{math.Inf(1), float64(k - 1), 0}
+    }
+    e := expected[i] / total * float64(n)
+    d := float64(c) - e
+    acc.Add(d * d / e)
+  }
+  chi2, df := acc.Sum(), float64(k-1)
+  return TestResult{chi2, df, ChiSquareSurvival(chi2, df)}
+}
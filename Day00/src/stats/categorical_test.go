@@ -0,0 +1,77 @@
+package stats
+
+import (
+  "errors"
+  "math"
+  "reflect"
+  "strings"
+  "testing"
+)
+
+func TestCategories(t *testing.T) {
+  got := Categories(strings.Fields("b a c b a b d"))
+  want := []Category{
+    {"b", 3, 3.0 / 7}, {"a", 2, 2.0 / 7}, {"c", 1, 1.0 / 7}, {"d", 1, 1.0 / 7},
+  }
+  if !reflect.DeepEqual(got, want) {
+    t.Errorf("Categories = %v, want %v", got, want)
+  }
+
+  for _, tt := range []struct {
+    counts []int
+    want   float64
+  }{
+    {[]int{5}, 0}, {[]int{1, 1}, 1}, {[]int{3, 3, 3, 3}, 2},
+    {[]int{1, 1, 2}, 1.5}, {nil, math.NaN()},
+  } {
+    if got := Entropy(tt.counts); !almostEqual(got, tt.want) {
+      t.Errorf("Entropy(%v) = %v, want %v", tt.counts, got, tt.want)
+    }
+  }
+}
+
+func TestChiSquare(t *testing.T) {
+  for _, tt := range []struct {
+    x, df, want float64
+  }{
+    {3.841458820694124, 1, 0.05},
+    {5.991464547107979, 2, 0.05},
+    {0.5, 4, 0.9735009788392561},
+    {30, 10, 0.0008566412},
+  } {
+    if got := ChiSquareSurvival(tt.x, tt.df); math.Abs(got-tt.want) > 1e-9 {
+      t.Errorf("ChiSquareSurvival(%v, %v) = %v, want %v",
+        tt.x, tt.df, got, tt.want)
+    }
+  }
+
+  // 60 rolls of a die.
+  res := ChiSquare([]int{5, 8, 9, 8, 10, 20}, nil)
+  if !almostEqual(res.Statistic, 13.4) || res.DF != 5 ||
+    math.Abs(res.P-0.019905) > 1e-6 {
+    t.Errorf("ChiSquare(die) = %+v, want 13.4, 5, 0.0199", res)
+  }
+  res = ChiSquare([]int{30, 10}, []float64{3, 1})
+  if res.Statistic != 0 || res.P != 1 {
+    t.Errorf("ChiSquare(expected) = %+v, want 0, p 1", res)
+  }
+  res = ChiSquare([]int{2, 1, 0}, []float64{1, 1, 0})
+  if !almostEqual(res.Statistic, 1.0/3) || res.DF != 1 || math.IsNaN(res.P) {
+    t.Errorf("ChiSquare(never expected) = %+v, want 1/3, 1", res)
+  }
+  res = ChiSquare([]int{2, 1, 1}, []float64{1, 1, 0})
+  if !math.IsInf(res.Statistic, 1) || res.DF != 2 || res.P != 0 {
+    t.Errorf("ChiSquare(unexpected) = %+v, want +Inf, 2, p 0", res)
+  }
+}
+
+func TestReadTokens(t *testing.T) {
+  var rd Reader
+  got, err := rd.ReadTokens(strings.NewReader(" red\nblue \nred green\n"))
+  if err != nil || !reflect.DeepEqual(got, []string{"red", "blue", "red green"}) {
+    t.Errorf("got %q %v", got, err)
+  }
+  if _, err := rd.ReadTokens(strings.NewReader("a\n\nb\n")); !errors.Is(err, ErrEmpty) {
+    t.Errorf("err = %v, want %v", err, ErrEmpty)
+  }
+}
@@ -0,0 +1,152 @@
+package stats
+
+import (
+  "bytes"
+  "io"
+  "runtime"
+  "strings"
+  "sync"
+  "sync/atomic"
+)
+
+// chunkSize is the approximate number of bytes parsed by a goroutine
+// of ReadChunked at once.
+var chunkSize = 1 << 20
+
+// chunk is a part of the input made of whole lines.
+type chunk struct {
+  index int
+  line  int // number of the first line
+  data  []byte
+
+  // Results of parsing.
+  nums    []float64
+  moments Moments
+  errs    []*ParseError
+}
+
+// ReadChunked reads numbers like ReadFloats, parsing chunks of the input
+// in parallel with the given number of goroutines, or one per CPU if
+// workers is not positive. It also returns the moments of the numbers,
+// accumulated per chunk and merged. Skip is called in the order of
+// the lines, from the calling goroutine.
+func (rd *Reader) ReadChunked(r io.Reader, workers int) ([]float64, *Moments, error) {
+  if workers <= 0 {
+    workers = runtime.GOMAXPROCS(0)
+  }
+
+  todo := make(chan *chunk, workers)
+  var done []*chunk
+  var mu sync.Mutex
+  var wg sync.WaitGroup
+  // Without Skip the input is read up to the first invalid line only.
+  var failed atomic.Bool
+  for i := 0; i < workers; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for c := range todo {
+        if !rd.parseChunk(c) {
+          failed.Store(true)
+        }
+        mu.Lock()
+        done = append(done, c)
+        mu.Unlock()
+      }
+    }()
+  }
+
+  err := split(r, func(c *chunk) bool {
+    todo <- c
+    return !failed.Load()
+  })
+  close(todo)
+  wg.Wait()
+  if err != nil {
+    return nil, nil, err
+  }
+
+  chunks := make([]*chunk, len(done))
+  n := 0
+  for _, c := range done {
+    chunks[c.index] = c
+    n += len(c.nums)
+  }
+
+  nums := make([]float64, 0, n)
+  var moments Moments
+  for _, c := range chunks {
+    for _, e := range c.errs {
+      if err := rd.fail(e.Line, e.Text, e.Err); err != nil {
+        return nil, nil, err
+      }
+    }
+    nums = append(nums, c.nums...)
+    moments.Merge(&c.moments)
+  }
+  return nums, &moments, nil
+}
+
+// split reads r and passes it to fn in chunks of whole lines until
+// the end of r or until fn returns false.
+func split(r io.Reader, fn func(*chunk) bool) error {
+  var rest []byte
+  index, line := 0, 1
+  for {
+    buf := make([]byte, len(rest), max(chunkSize, 2*len(rest)))
+    copy(buf, rest)
+    n, err := io.ReadFull(r, buf[len(rest):cap(buf)])
+    buf = buf[:len(rest)+n]
+
+    eof := err == io.EOF || err == io.ErrUnexpectedEOF
+    if err != nil && !eof {
+      return err
+    }
+    // A line longer than the buffer is carried over to a larger one.
+    end := len(buf)
+    if !eof {
+      end = bytes.LastIndexByte(buf, '\n') + 1
+    }
+    rest = buf[end:]
+
+    if end != 0 {
+      c := &chunk{index: index, line: line, data: buf[:end]}
+      index++
+      line += bytes.Count(c.data, []byte{'\n'})
+      if !fn(c) {
+        return nil
+      }
+    }
+    if eof {
+      return nil
+    }
+  }
+}
+
+// parseChunk parses the lines of c like scan does. Without Skip,
+// parsing stops at the first invalid line and it returns false.
+func (rd *Reader) parseChunk(c *chunk) bool {
+  data := c.data
+  for line := c.line; len(data) != 0; line++ {
+    text := data
+    if i := bytes.IndexByte(data, '\n'); i >= 0 {
+      text, data = data[:i], data[i+1:]
+    } else {
+      data = nil
+    }
+
+    s := strings.TrimSpace(string(text))
+    num, err := ParseFloat(s, rd.Range)
+    if err != nil {
+      c.errs = append(c.errs, &ParseError{line, s, err})
+      if rd.Skip == nil {
+        return false
+      }
+      continue
+    }
+    c.nums = append(c.nums, num)
+    c.moments.Add(num)
+  }
+  c.data = nil
+  return true
+}
@@ -0,0 +1,142 @@
+package stats
+
+import (
+  "bufio"
+  "errors"
+  "fmt"
+  "io"
+  "math/rand"
+  "slices"
+  "strconv"
+  "strings"
+  "testing"
+)
+
+func TestReadChunked(t *testing.T) {
+  defer func(n int) { chunkSize = n }(chunkSize)
+  chunkSize = 7
+
+  in := "1\n22\n-3.5\n\n 4e2 \r\nx\n123456789012\n6"
+  var want, got []*ParseError
+  rd := Reader{Range: Unbounded, Skip: func(e *ParseError) { want = append(want, e) }}
+  nums, err := rd.ReadFloats(strings.NewReader(in))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, workers := range []int{1, 3} {
+    got = nil
+    rd.Skip = func(e *ParseError) { got = append(got, e) }
+    chunked, m, err := rd.ReadChunked(strings.NewReader(in), workers)
+    if err != nil || !slices.Equal(chunked, nums) ||
+      fmt.Sprint(got) != fmt.Sprint(want) {
+      t.Errorf("%d workers: got %v %v %v, want %v %v",
+        workers, chunked, got, err, nums, want)
+    }
+    if s := NewSample(nums); m.Len() != s.Len() ||
+      !almostEqual(m.Mean(), s.Mean()) ||
+      !almostEqual(m.Variance(0), s.Variance(0)) {
+      t.Errorf("%d workers: moments n=%d mean=%v var=%v",
+        workers, m.Len(), m.Mean(), m.Variance(0))
+    }
+  }
+
+  rd.Skip = nil
+  _, _, err = rd.ReadChunked(strings.NewReader(in), 2)
+  var e *ParseError
+  if !errors.As(err, &e) || e.Line != 4 || !errors.Is(err, ErrEmpty) {
+    t.Errorf("err = %v, want line 4 empty", err)
+  }
+}
+
+func TestQuantileSelect(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  for _, n := range []int{1, 2, 5, 17, 100, 1001} {
+    xs := make([]float64, n)
+    for i := range xs {
+      xs[i] = float64(rng.Intn(n/2 + 1))
+    }
+    s := NewSample(xs)
+    for _, p := range []float64{0, 0.1, 0.25, 0.5, 0.9, 1} {
+      if got, want := QuantileSelect(xs, p), s.Quantile(p); !almostEqual(got, want) {
+        t.Errorf("n=%d: QuantileSelect(%v) = %v, want %v", n, p, got, want)
+      }
+    }
+  }
+}
+
+// benchInput returns n random numbers, one per line.
+func benchInput(n int) string {
+  rng := rand.New(rand.NewSource(1))
+  var b strings.Builder
+  for i := 0; i < n; i++ {
+    b.WriteString(strconv.Itoa(rng.Intn(200001) - 100000))
+    b.WriteByte('\n')
+  }
+  return b.String()
+}
+
+// BenchmarkScanln reads numbers with fmt.Scanln, as anscombe did
+// before the stats package.
+func BenchmarkScanln(b *testing.B) {
+  in := benchInput(100000)
+  b.SetBytes(int64(len(in)))
+  for i := 0; i < b.N; i++ {
+    r := bufio.NewReader(strings.NewReader(in))
+    for {
+      var num int
+      if _, err := fmt.Fscanln(r, &num); err == io.EOF {
+        break
+      } else if err != nil {
+        b.Fatal(err)
+      }
+    }
+  }
+}
+
+func BenchmarkReadFloats(b *testing.B) {
+  in := benchInput(100000)
+  rd := Reader{Range: Unbounded}
+  b.SetBytes(int64(len(in)))
+  for i := 0; i < b.N; i++ {
+    if _, err := rd.ReadFloats(strings.NewReader(in)); err != nil {
+      b.Fatal(err)
+    }
+  }
+}
+
+// BenchmarkReadChunked reads an input of several chunks with several
+// numbers of workers, comparable to BenchmarkReadFloats by throughput.
+func BenchmarkReadChunked(b *testing.B) {
+  in := benchInput(1000000)
+  if len(in) < 4*chunkSize {
+    b.Fatalf("input of %d bytes is less than 4 chunks", len(in))
+  }
+  rd := Reader{Range: Unbounded}
+  for _, workers := range []int{1, 2, 4, 8} {
+    b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+      b.SetBytes(int64(len(in)))
+      for i := 0; i < b.N; i++ {
+        if _, _, err := rd.ReadChunked(strings.NewReader(in), workers); err != nil {
+          b.Fatal(err)
+        }
+      }
+    })
+  }
+}
+
+func BenchmarkMedianSort(b *testing.B) {
+  xs, _ := (&Reader{Range: Unbounded}).ReadFloats(strings.NewReader(benchInput(100000)))
+  for i := 0; i < b.N; i++ {
+    NewSample(xs).Median()
+  }
+}
+
+func BenchmarkMedianSelect(b *testing.B) {
+  xs, _ := (&Reader{Range: Unbounded}).ReadFloats(strings.NewReader(benchInput(100000)))
+  ys := make([]float64, len(xs))
+  for i := 0; i < b.N; i++ {
+    copy(ys, xs)
+    MedianSelect(ys)
+  }
+}
@@ -0,0 +1,102 @@
+package stats
+
+import (
+  "math"
+  "slices"
+)
+
+// TestResult is the outcome of a hypothesis test.
+type TestResult struct {
+  Statistic float64
+  // DF is the number of degrees of freedom, if the test has them.
+  DF float64
+  // P is the two-sided p-value: the probability of a statistic at
+  // least as extreme under the null hypothesis.
+  P float64
+}
+
+// WelchTTest tests whether the samples a and b come from populations
+// with equal means, without assuming equal variances. The statistic is
+// positive if the mean of b is greater.
+func WelchTTest[T Number](a, b []T) TestResult {
+  fa, fb := toFloats(a), toFloats(b)
+  na, nb := float64(len(fa)), float64(len(fb))
+
+  va := withDDOF(variance(fa), len(fa), 1) / na
+  vb := withDDOF(variance(fb), len(fb), 1) / nb
+  t := (mean(fb) - mean(fa)) / math.Sqrt(va+vb)
+  df := (va + vb) * (va + vb) / (va*va/(na-1) + vb*vb/(nb-1))
+
+  return TestResult{t, df, 2 * StudentTCDF(-math.Abs(t), df)}
+}
+
+// MannWhitneyU tests whether a value of b is as likely to be greater
+// than a value of a as to be smaller. The statistic is U of the sample
+// a, the p-value is by the normal approximation corrected for ties and
+// continuity.
+func MannWhitneyU[T Number](a, b []T) TestResult {
+  fa, fb := toFloats(a), toFloats(b)
+  na, nb := float64(len(fa)), float64(len(fb))
+  n := na + nb
+
+  r := ranks(append(slices.Clip(fa), fb...))
+  var ra float64
+  for _, x := range r[:len(fa)] {
+    ra += x
+  }
+  u := ra - na*(na+1)/2
+
+  // Ties reduce the variance of U by the sum of t^3-t over the groups
+  // of t equal ranks.
+  slices.Sort(r)
+  var ties float64
+  for i := 0; i < len(r); {
+    j := i + 1
+    for j < len(r) && r[j] == r[i] {
+      j++
+    }
+    t := float64(j - i)
+    ties += t*t*t - t
+    i = j
+  }
+
+  mu := na * nb / 2
+  sigma := math.Sqrt(na * nb / 12 * ((n + 1) - ties/(n*(n-1))))
+  z := math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+  return TestResult{Statistic: u, P: math.Min(2*(1-NormalCDF(z)), 1)}
+}
+
+// KolmogorovSmirnov tests whether the samples a and b come from the same
+// distribution. The statistic is the largest distance between their
+// empirical distribution functions, the p-value is asymptotic.
+func KolmogorovSmirnov[T Number](a, b []T) TestResult {
+  sa, sb := NewSample(a).xs, NewSample(b).xs
+  na, nb := float64(len(sa)), float64(len(sb))
+
+  var d float64
+  i, j := 0, 0
+  for i < len(sa) && j < len(sb) {
+    x := math.Min(sa[i], sb[j])
+    for i < len(sa) && sa[i] == x {
+      i++
+    }
+    for j < len(sb) && sb[j] == x {
+      j++
+    }
+    d = math.Max(d, math.Abs(float64(i)/na-float64(j)/nb))
+  }
+
+  en := math.Sqrt(na * nb / (na + nb))
+  p := KolmogorovSurvival((en + 0.12 + 0.11/en) * d)
+  return TestResult{Statistic: d, P: p}
+}
+
+// CohensD returns the effect size of the difference between the means
+// of b and a in units of their pooled standard deviation.
+func CohensD[T Number](a, b []T) float64 {
+  fa, fb := toFloats(a), toFloats(b)
+  na, nb := float64(len(fa)), float64(len(fb))
+
+  pooled := (na*variance(fa) + nb*variance(fb)) / (na + nb - 2)
+  return (mean(fb) - mean(fa)) / math.Sqrt(pooled)
+}
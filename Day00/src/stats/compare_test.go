@@ -0,0 +1,58 @@
+package stats
+
+import (
+  "math"
+  "testing"
+)
+
+func TestDistributions(t *testing.T) {
+  tests := []struct {
+    name      string
+    got, want float64
+  }{
+    {"NormalCDF(0)", NormalCDF(0), 0.5},
+    {"NormalCDF(1.96)", NormalCDF(1.959963984540054), 0.975},
+    {"StudentTCDF(0, 5)", StudentTCDF(0, 5), 0.5},
+    {"StudentTCDF(2.228, 10)", StudentTCDF(2.228138851986274, 10), 0.975},
+    {"StudentTCDF(-1, 1)", StudentTCDF(-1, 1), 0.25},
+    {"KolmogorovSurvival(1.358)", KolmogorovSurvival(1.358098), 0.05},
+  }
+
+  for _, tt := range tests {
+    if math.Abs(tt.got-tt.want) > 1e-6 {
+      t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+    }
+  }
+}
+
+func TestCompare(t *testing.T) {
+  // The example of Welch's t-test from Wikipedia.
+  a := []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6,
+    23.1, 19.6, 19.0, 21.7, 21.4}
+  b := []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2,
+    21.9, 22.1, 22.9, 20.5, 24.4}
+
+  welch := WelchTTest(a, b)
+  mw := MannWhitneyU(a, b)
+  ks := KolmogorovSmirnov(a, b)
+  tests := []struct {
+    name      string
+    got, want float64
+    tol       float64
+  }{
+    {"Welch t", welch.Statistic, 2.46, 0.005},
+    {"Welch df", welch.DF, 24.99, 0.005},
+    {"Welch p", welch.P, 0.021, 0.0005},
+    {"Mann-Whitney U", mw.Statistic, 53.5, 0},
+    {"Mann-Whitney p", mw.P, 0.0152, 0.0001},
+    {"KS D", ks.Statistic, 7.0 / 15, 1e-12},
+    {"KS p", ks.P, 0.0515, 0.0005},
+    {"Cohen's d", CohensD(a, b), 0.8966, 0.0001},
+  }
+
+  for _, tt := range tests {
+    if math.Abs(tt.got-tt.want) > tt.tol {
+      t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+    }
+  }
+}
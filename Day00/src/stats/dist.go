@@ -0,0 +1,199 @@
+package stats
+
+import "math"
+
+// NormalCDF returns the cumulative distribution function of the standard
+// normal distribution at x.
+func NormalCDF(x float64) float64 {
+  return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// StudentTCDF returns the cumulative distribution function of Student's
+// t-distribution with df degrees of freedom at t.
+func StudentTCDF(t, df float64) float64 {
+  tail := 0.5 * regIncBeta(df/2, 0.5, df/(df+t*t))
+  if t > 0 {
+    return 1 - tail
+  }
+  return tail
+}
+
+// KolmogorovSurvival returns the probability that the Kolmogorov
+// distribution exceeds x, the limiting distribution of sqrt(n) times
+// the Kolmogorov-Smirnov statistic.
+func KolmogorovSurvival(x float64) float64 {
+  if x < 0.2 {
+    return 1
+  }
+
+  var sum float64
+  sign := 1.0
+  for k := 1; k <= 100; k++ {
+    term := sign * math.Exp(-2*float64(k*k)*x*x)
+    sum += term
+    if math.Abs(term) < 1e-12 {
+      break
+    }
+    sign = -sign
+  }
+  return math.Min(math.Max(2*sum, 0), 1)
+}
+
+// ChiSquareSurvival returns the probability that the chi-squared
+// distribution with df degrees of freedom exceeds x.
+func ChiSquareSurvival(x, df float64) float64 {
+  if x <= 0 {
+    return 1
+  }
+  return regIncGammaUpper(df/2, x/2)
+}
+
+// regIncGammaUpper returns the regularized upper incomplete gamma
+// function Q(a, x), by its series below a+1 and by its continued
+// fraction, evaluated with the modified Lentz's method, above.
+func regIncGammaUpper(a, x float64) float64 {
+  const tiny = 1e-300
+  const eps = 1e-15
+
+  lg, _ := math.Lgamma(a)
+  front := math.Exp(a*math.Log(x) - x - lg)
+
+  if x < a+1 {
+    term, sum := 1/a, 1/a
+    for n := 1; n <= 1000 && math.Abs(term) > math.Abs(sum)*eps; n++ {
+      term *= x / (a + float64(n))
+      sum += term
+    }
+    return math.Max(1-front*sum, 0)
+  }
+
+  b := x + 1 - a
+  c, d := 1/tiny, 1/b
+  h := d
+  for n := 1; n <= 1000; n++ {
+    num := -float64(n) * (float64(n) - a)
+    b += 2
+    d = num*d + b
+    if math.Abs(d) < tiny {
+      d = tiny
+    }
+    c = b + num/c
+    if math.Abs(c) < tiny {
+      c = tiny
+    }
+    d = 1 / d
+    h *= d * c
+    if math.Abs(d*c-1) < eps {
+      break
+    }
+  }
+  return front * h
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b).
+func regIncBeta(a, b, x float64) float64 {
+  if x <= 0 {
+    return 0
+  }
+  if x >= 1 {
+    return 1
+  }
+
+  la, _ := math.Lgamma(a)
+  lb, _ := math.Lgamma(b)
+  lab, _ := math.Lgamma(a + b)
+  front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+  // The continued fraction converges quickly for x below the mean of
+  // the distribution, use the symmetry I_x(a, b) = 1 - I_1-x(b, a)
+  // otherwise.
+  if x < (a+1)/(a+b+2) {
+    return front * betaFraction(a, b, x) / a
+  }
+  return 1 - front*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction evaluates the continued fraction of the incomplete beta
+// function by the modified Lentz's method.
+func betaFraction(a, b, x float64) float64 {
+  const tiny = 1e-300
+  const eps = 1e-15
+
+  c, d := 1.0, 1-(a+b)*x/(a+1)
+  if math.Abs(d) < tiny {
+    d = tiny
+  }
+  d = 1 / d
+  h := d
+
+  for m := 1; m <= 300; m++ {
+    fm := float64(m)
+    for _, num := range []float64{
+      fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+      -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+    } {
+      d = 1 + num*d
+      if math.Abs(d) < tiny {
+        d = tiny
+      }
+      c = 1 + num/c
+      if math.Abs(c) < tiny {
+        c = tiny
+      }
+      d = 1 / d
+      h *= d * c
+    }
+    if math.Abs(d*c-1) < eps {
+      break
+    }
+  }
+  return h
+}
+
+// NormalQuantile returns the p-quantile of the standard normal
+// distribution, the inverse of NormalCDF.
+func NormalQuantile(p float64) float64 {
+  switch {
+  case p <= 0:
+    return math.Inf(-1)
+  case p >= 1:
+    return math.Inf(1)
+  case math.IsNaN(p):
+    return math.NaN()
+  }
+
+  // Acklam's rational approximation, refined by a step of Halley's
+  // method to full double precision.
+  a := [...]float64{-3.969683028665376e+01, 2.209460984245205e+02,
+    -2.759285104469687e+02, 1.383577518672690e+02,
+    -3.066479806614716e+01, 2.506628277459239e+00}
+  b := [...]float64{-5.447609879822406e+01, 1.615858368580409e+02,
+    -1.556989798598866e+02, 6.680131188771972e+01,
+    -1.328068155288572e+01}
+  c := [...]float64{-7.784894002430293e-03, -3.223964580411365e-01,
+    -2.400758277161838e+00, -2.549732539343734e+00,
+    4.374664141464968e+00, 2.938163982698783e+00}
+  d := [...]float64{7.784695709041462e-03, 3.224671290700398e-01,
+    2.445134137142996e+00, 3.754408661907416e+00}
+
+  var x float64
+  switch {
+  case p < 0.02425:
+    q := math.Sqrt(-2 * math.Log(p))
+    x = (((((c[0]*q+c[1])*q+c[2])*q+c[3])*q+c[4])*q + c[5]) /
+      ((((d[0]*q+d[1])*q+d[2])*q+d[3])*q + 1)
+  case p > 1-0.02425:
+    q := math.Sqrt(-2 * math.Log(1-p))
+    x = -(((((c[0]*q+c[1])*q+c[2])*q+c[3])*q+c[4])*q + c[5]) /
+      ((((d[0]*q+d[1])*q+d[2])*q+d[3])*q + 1)
+  default:
+    q := p - 0.5
+    r := q * q
+    x = (((((a[0]*r+a[1])*r+a[2])*r+a[3])*r+a[4])*r + a[5]) * q /
+      (((((b[0]*r+b[1])*r+b[2])*r+b[3])*r+b[4])*r + 1)
+  }
+
+  e := NormalCDF(x) - p
+  u := e * math.Sqrt(2*math.Pi) * math.Exp(x*x/2)
+  return x - u/(1+x*u/2)
+}
@@ -0,0 +1,171 @@
+package stats
+
+import (
+  "bufio"
+  "io"
+  "math/big"
+  "slices"
+  "strings"
+)
+
+// ParseDecimal parses a number in decimal or scientific notation into
+// an exact rational and checks that it lies within rg. The errors are
+// those of ParseFloat.
+func ParseDecimal(s string, rg Range) (*big.Rat, error) {
+  if _, err := ParseFloat(s, rg); err != nil {
+    return nil, err
+  }
+  // Hexadecimal floats and the forms of NaN and infinity accepted by
+  // ParseFloat are not decimals.
+  x, ok := new(big.Rat).SetString(s)
+  if !ok || strings.ContainsAny(s, "xX_") {
+    return nil, ErrNotNumber
+  }
+  return x, nil
+}
+
+// ReadDecimals reads numbers like ReadFloats but as exact rationals.
+func (rd *Reader) ReadDecimals(r io.Reader) ([]*big.Rat, error) {
+  var nums []*big.Rat
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    text := strings.TrimSpace(scanner.Text())
+    num, err := ParseDecimal(text, rd.Range)
+    if err != nil {
+      if err = rd.fail(line, text, err); err != nil {
+        return nil, err
+      }
+      continue
+    }
+    nums = append(nums, num)
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, err
+  }
+  return nums, nil
+}
+
+// ExactSample is a sorted set of observations whose statistics are
+// computed without rounding. Statistics of an empty sample, and those
+// undefined for the sample size, are nil.
+type ExactSample struct {
+  xs []*big.Rat
+}
+
+// NewExactSample returns the sample of the values, sorted. The values
+// must not be modified afterwards.
+func NewExactSample(values []*big.Rat) *ExactSample {
+  xs := slices.Clone(values)
+  slices.SortFunc(xs, (*big.Rat).Cmp)
+  return &ExactSample{xs}
+}
+
+// Len returns the number of observations.
+func (s *ExactSample) Len() int {
+  return len(s.xs)
+}
+
+// Sum returns the sum of the observations.
+func (s *ExactSample) Sum() *big.Rat {
+  sum := new(big.Rat)
+  for _, x := range s.xs {
+    sum.Add(sum, x)
+  }
+  return sum
+}
+
+// Mean returns the arithmetic mean.
+func (s *ExactSample) Mean() *big.Rat {
+  if len(s.xs) == 0 {
+    return nil
+  }
+  n := new(big.Rat).SetInt64(int64(len(s.xs)))
+  return n.Quo(s.Sum(), n)
+}
+
+// Median returns the middle observation if their count is odd, and
+// the average of the two middle ones otherwise.
+func (s *ExactSample) Median() *big.Rat {
+  n := len(s.xs)
+  if n == 0 {
+    return nil
+  }
+  if n%2 != 0 {
+    return new(big.Rat).Set(s.xs[n/2])
+  }
+  m := new(big.Rat).Add(s.xs[n/2-1], s.xs[n/2])
+  return m.Quo(m, big.NewRat(2, 1))
+}
+
+// Mode returns the most frequent observation, the smallest one on ties.
+func (s *ExactSample) Mode() *big.Rat {
+  var mode *big.Rat
+  best := 0
+  for i := 0; i < len(s.xs); {
+    j := i + 1
+    for j < len(s.xs) && s.xs[j].Cmp(s.xs[i]) == 0 {
+      j++
+    }
+    if j-i > best {
+      mode, best = s.xs[i], j-i
+    }
+    i = j
+  }
+  if mode == nil {
+    return nil
+  }
+  return new(big.Rat).Set(mode)
+}
+
+// Min returns the smallest observation.
+func (s *ExactSample) Min() *big.Rat {
+  if len(s.xs) == 0 {
+    return nil
+  }
+  return new(big.Rat).Set(s.xs[0])
+}
+
+// Max returns the largest observation.
+func (s *ExactSample) Max() *big.Rat {
+  if len(s.xs) == 0 {
+    return nil
+  }
+  return new(big.Rat).Set(s.xs[len(s.xs)-1])
+}
+
+// Range returns the difference between the largest and the smallest
+// observations.
+func (s *ExactSample) Range() *big.Rat {
+  if len(s.xs) == 0 {
+    return nil
+  }
+  return new(big.Rat).Sub(s.xs[len(s.xs)-1], s.xs[0])
+}
+
+// Variance returns the sum of squared deviations from the mean divided
+// by n-ddof, see Sample.Variance.
+func (s *ExactSample) Variance(ddof int) *big.Rat {
+  n := len(s.xs)
+  if n <= ddof {
+    return nil
+  }
+
+  avg := s.Mean()
+  ss, d := new(big.Rat), new(big.Rat)
+  for _, x := range s.xs {
+    d.Sub(x, avg)
+    ss.Add(ss, d.Mul(d, d))
+  }
+  return ss.Quo(ss, new(big.Rat).SetInt64(int64(n-ddof)))
+}
+
+// StdDev returns the standard deviation with the given ddof, the square
+// root of the variance rounded to prec bits of mantissa.
+func (s *ExactSample) StdDev(ddof int, prec uint) *big.Float {
+  v := s.Variance(ddof)
+  if v == nil {
+    return nil
+  }
+  f := new(big.Float).SetPrec(prec).SetRat(v)
+  return f.Sqrt(f)
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+  "errors"
+  "math"
+  "math/big"
+  "math/rand"
+  "strconv"
+  "strings"
+  "testing"
+)
+
+// decimals parses the numbers of the text, one per line.
+func decimals(t *testing.T, text string) []*big.Rat {
+  t.Helper()
+  nums, err := (&Reader{Range: Unbounded}).ReadDecimals(strings.NewReader(text))
+  if err != nil {
+    t.Fatal(err)
+  }
+  return nums
+}
+
+func TestParseDecimal(t *testing.T) {
+  for _, tt := range []struct {
+    in   string
+    want string // exact fraction
+    err  error
+  }{
+    {"0.1", "1/10", nil},
+    {"-2.50", "-5/2", nil},
+    {"1e-3", "1/1000", nil},
+    {".5", "1/2", nil},
+    {"+7", "7", nil},
+    {"1/3", "", ErrNotNumber},
+    {"0x1p-2", "", ErrNotNumber},
+    {"NaN", "", ErrNotNumber},
+    {"Inf", "", ErrRange},
+    {"200001", "", ErrRange},
+    {"", "", ErrEmpty},
+  } {
+    got, err := ParseDecimal(tt.in, Range{-1e5, 1e5})
+    if !errors.Is(err, tt.err) || err == nil && got.RatString() != tt.want {
+      t.Errorf("ParseDecimal(%q) = %v, %v, want %s, %v",
+        tt.in, got, err, tt.want, tt.err)
+    }
+  }
+}
+
+// TestExactSample checks exact results where float64 rounds.
+func TestExactSample(t *testing.T) {
+  s := NewExactSample(decimals(t, strings.Repeat("0.1\n", 10)+"0.2\n0.3\n"))
+  for _, tt := range []struct {
+    metric string
+    got    *big.Rat
+    want   string
+  }{
+    {"Sum", s.Sum(), "3/2"},
+    {"Mean", s.Mean(), "1/8"},
+    {"Median", s.Median(), "1/10"},
+    {"Mode", s.Mode(), "1/10"},
+    {"Range", s.Range(), "1/5"},
+    {"Variance(0)", s.Variance(0), "17/4800"},
+    {"Variance(1)", s.Variance(1), "17/4400"},
+  } {
+    if tt.got.RatString() != tt.want {
+      t.Errorf("%s = %s, want %s", tt.metric, tt.got.RatString(), tt.want)
+    }
+  }
+
+  // Cancellation loses the variance of large numbers in float64
+  // arithmetic, but not in exact arithmetic.
+  large := NewExactSample(decimals(t, "1e17\n1e17\n100000000000000001\n"))
+  if got := large.Variance(0).RatString(); got != "2/9" {
+    t.Errorf("Variance of large numbers = %s, want 2/9", got)
+  }
+
+  sd := NewExactSample(decimals(t, "1\n2\n3\n4\n")).StdDev(0, 200)
+  if got, _ := sd.Float64(); got != math.Sqrt(1.25) {
+    t.Errorf("StdDev(0) = %v, want %v", got, math.Sqrt(1.25))
+  }
+
+  empty := NewExactSample(nil)
+  if empty.Mean() != nil || empty.Median() != nil || empty.Mode() != nil ||
+    empty.Variance(0) != nil || empty.StdDev(1, 64) != nil {
+    t.Error("statistics of an empty sample are not nil")
+  }
+}
+
+// TestExactAgainstFloat checks that the exact statistics of random
+// decimal samples agree with the float64 ones up to rounding.
+func TestExactAgainstFloat(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  for trial := 0; trial < 50; trial++ {
+    var text strings.Builder
+    n := 1 + rng.Intn(200)
+    for i := 0; i < n; i++ {
+      x := float64(rng.Intn(2000001)-1000000) / 100
+      text.WriteString(strconv.FormatFloat(x, 'f', 2, 64) + "\n")
+    }
+    nums, err := (&Reader{Range: Unbounded}).ReadFloats(strings.NewReader(text.String()))
+    if err != nil {
+      t.Fatal(err)
+    }
+    s, e := NewSample(nums), NewExactSample(decimals(t, text.String()))
+
+    for _, tt := range []struct {
+      metric string
+      float  float64
+      exact  *big.Rat
+    }{
+      {"Sum", s.Sum(), e.Sum()},
+      {"Mean", s.Mean(), e.Mean()},
+      {"Median", s.Median(), e.Median()},
+      {"Mode", s.Mode(), e.Mode()},
+      {"Min", s.Min(), e.Min()},
+      {"Max", s.Max(), e.Max()},
+      {"Variance(0)", s.Variance(0), e.Variance(0)},
+    } {
+      want, _ := tt.exact.Float64()
+      if math.Abs(tt.float-want) > 1e-9*math.Max(1, math.Abs(want)) {
+        t.Errorf("n=%d: %s = %v, exact %v", n, tt.metric, tt.float, want)
+      }
+    }
+    sd, _ := e.StdDev(0, 128).Float64()
+    if !almostEqual(s.StdDev(), sd) {
+      t.Errorf("n=%d: StdDev = %v, exact %v", n, s.StdDev(), sd)
+    }
+  }
+}
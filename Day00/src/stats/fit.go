@@ -0,0 +1,148 @@
+package stats
+
+import (
+  "math"
+  "slices"
+)
+
+// Param is a named parameter of a fitted distribution.
+type Param struct {
+  Name  string
+  Value float64
+}
+
+// Fit is a distribution fitted to a sample by maximum likelihood.
+type Fit struct {
+  Name   string
+  Params []Param
+  // LogLikelihood is the log-likelihood of the sample under the fitted
+  // distribution and AIC is Akaike's information criterion, lower for
+  // a better fit.
+  LogLikelihood, AIC float64
+  // KS is the Kolmogorov-Smirnov distance between the sample and the
+  // fitted distribution.
+  KS float64
+
+  cdf func(x float64) float64
+}
+
+// CDF returns the cumulative distribution function of the fitted
+// distribution at x.
+func (f *Fit) CDF(x float64) float64 {
+  return f.cdf(x)
+}
+
+// FitNormal fits the normal distribution to xs. It reports false if
+// there are fewer than two distinct observations.
+func FitNormal[T Number](xs []T) (Fit, bool) {
+  s := NewSample(xs)
+  if s.Range() == 0 || s.Len() < 2 {
+    return Fit{}, false
+  }
+  mu, sd := s.Mean(), s.StdDev()
+  n := float64(s.Len())
+  ll := -n/2*math.Log(2*math.Pi*sd*sd) - n/2
+  return fit(s, "normal", ll, func(x float64) float64 {
+    return NormalCDF((x - mu) / sd)
+  }, Param{"mean", mu}, Param{"sd", sd}), true
+}
+
+// FitLogNormal fits the log-normal distribution to xs. It reports false
+// if an observation is not positive or there are fewer than two
+// distinct observations.
+func FitLogNormal[T Number](xs []T) (Fit, bool) {
+  s := NewSample(xs)
+  if s.Range() == 0 || s.Len() < 2 || !(s.Min() > 0) {
+    return Fit{}, false
+  }
+  logs := make([]float64, s.Len())
+  for i, x := range s.xs {
+    logs[i] = math.Log(x)
+  }
+  mu, sigma := mean(logs), math.Sqrt(variance(logs))
+  n := float64(s.Len())
+  ll := -sum(logs) - n/2*math.Log(2*math.Pi*sigma*sigma) - n/2
+  return fit(s, "lognormal", ll, func(x float64) float64 {
+    if x <= 0 {
+      return 0
+    }
+    return NormalCDF((math.Log(x) - mu) / sigma)
+  }, Param{"mu", mu}, Param{"sigma", sigma}), true
+}
+
+// FitExponential fits the exponential distribution to xs. It reports
+// false if an observation is negative or all of them are zero.
+func FitExponential[T Number](xs []T) (Fit, bool) {
+  s := NewSample(xs)
+  if s.Len() == 0 || s.Min() < 0 || s.Max() == 0 {
+    return Fit{}, false
+  }
+  rate := 1 / s.Mean()
+  n := float64(s.Len())
+  ll := n*math.Log(rate) - n
+  return fit(s, "exponential", ll, func(x float64) float64 {
+    if x <= 0 {
+      return 0
+    }
+    return -math.Expm1(-rate * x)
+  }, Param{"rate", rate}), true
+}
+
+// FitUniform fits the continuous uniform distribution to xs, whose
+// bounds are the smallest and the largest observations. It reports
+// false if there are fewer than two distinct observations.
+func FitUniform[T Number](xs []T) (Fit, bool) {
+  s := NewSample(xs)
+  if s.Range() == 0 || s.Len() < 2 {
+    return Fit{}, false
+  }
+  lo, hi := s.Min(), s.Max()
+  ll := -float64(s.Len()) * math.Log(hi-lo)
+  return fit(s, "uniform", ll, func(x float64) float64 {
+    return math.Max(0, math.Min((x-lo)/(hi-lo), 1))
+  }, Param{"min", lo}, Param{"max", hi}), true
+}
+
+// FitDistributions fits every distribution the sample xs allows and
+// returns them from the best to the worst by the Kolmogorov-Smirnov
+// distance. AIC favors the uniform distribution, whose likelihood is
+// inflated by bounds fitted to the extreme observations.
+func FitDistributions[T Number](xs []T) []Fit {
+  var fits []Fit
+  for _, fn := range []func([]T) (Fit, bool){
+    FitNormal[T], FitLogNormal[T], FitExponential[T], FitUniform[T],
+  } {
+    if f, ok := fn(xs); ok {
+      fits = append(fits, f)
+    }
+  }
+  slices.SortStableFunc(fits, func(a, b Fit) int {
+    switch {
+    case a.KS < b.KS:
+      return -1
+    case a.KS > b.KS:
+      return 1
+    }
+    return 0
+  })
+  return fits
+}
+
+// fit returns the Fit of the sample with the log-likelihood ll and
+// the cumulative distribution function cdf.
+func fit(s *Sample, name string, ll float64, cdf func(float64) float64, params ...Param) Fit {
+  n := float64(s.Len())
+  var d float64
+  for i, x := range s.xs {
+    f := cdf(x)
+    d = math.Max(d, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+  }
+  return Fit{
+    Name:          name,
+    Params:        params,
+    LogLikelihood: ll,
+    AIC:           2*float64(len(params)) - 2*ll,
+    KS:            d,
+    cdf:           cdf,
+  }
+}
@@ -0,0 +1,51 @@
+package stats
+
+// Frequency is a row of a frequency table.
+type Frequency struct {
+  Value float64
+  // Count is the number of occurrences of Value and Cumulative is
+  // the number of observations less than or equal to Value.
+  Count, Cumulative int
+  // Relative and CumRelative are Count and Cumulative divided by
+  // the sample size.
+  Relative, CumRelative float64
+}
+
+// Frequencies returns the frequency table of the sample, one row for
+// every distinct observation, in ascending order of values.
+func (s *Sample) Frequencies() []Frequency {
+  var table []Frequency
+
+  n := float64(len(s.xs))
+  for i := 0; i < len(s.xs); {
+    j := i + 1
+    for j < len(s.xs) && s.xs[j] == s.xs[i] {
+      j++
+    }
+    table = append(table, Frequency{
+      Value:       s.xs[i],
+      Count:       j - i,
+      Cumulative:  j,
+      Relative:    float64(j-i) / n,
+      CumRelative: float64(j) / n,
+    })
+    i = j
+  }
+
+  return table
+}
+
+// Modes returns the rows of the frequency table with the highest count,
+// in ascending order of values. The sample is multimodal if there are
+// more than one.
+func (s *Sample) Modes() []Frequency {
+  var modes []Frequency
+  for _, f := range s.Frequencies() {
+    if len(modes) == 0 || f.Count > modes[0].Count {
+      modes = append(modes[:0], f)
+    } else if f.Count == modes[0].Count {
+      modes = append(modes, f)
+    }
+  }
+  return modes
+}
@@ -0,0 +1,130 @@
+package stats
+
+import (
+  "math"
+  "sort"
+)
+
+// Bin is a class of a histogram, the interval [Lo, Hi). The last bin
+// of a histogram also includes Hi.
+type Bin struct {
+  Lo, Hi float64
+  Count  int
+}
+
+// Histogram divides the range of the sample into n bins of equal width
+// and counts the observations in each of them.
+func (s *Sample) Histogram(n int) []Bin {
+  if len(s.xs) == 0 || n < 1 {
+    return nil
+  }
+
+  lo, hi := s.Min(), s.Max()
+  if lo == hi {
+    return []Bin{{lo, hi, len(s.xs)}}
+  }
+  width := (hi - lo) / float64(n)
+  if math.IsInf(width, 0) {
+    // The range overflows, so the edges are interpolated between
+    // the extremes instead.
+    return s.histogram(n, func(i int) float64 {
+      t := float64(i) / float64(n)
+      return lo*(1-t) + hi*t
+    })
+  }
+  return s.histogram(n, func(i int) float64 { return lo + float64(i)*width })
+}
+
+// HistogramWidth counts the observations in bins of the given width,
+// starting at the smallest observation. It returns nil if the number
+// of bins is not finite.
+func (s *Sample) HistogramWidth(width float64) []Bin {
+  if len(s.xs) == 0 || !(width > 0) {
+    return nil
+  }
+
+  lo := s.Min()
+  n := math.Floor((s.Max()-lo)/width) + 1
+  if !(n < math.MaxInt32) {
+    return nil
+  }
+  return s.histogram(int(n), func(i int) float64 { return lo + float64(i)*width })
+}
+
+// histogram counts the observations in n bins, the i-th of them from
+// edge(i) to edge(i+1).
+func (s *Sample) histogram(n int, edge func(i int) float64) []Bin {
+  bins := make([]Bin, n)
+  for i := range bins {
+    bins[i].Lo = edge(i)
+    bins[i].Hi = edge(i + 1)
+  }
+
+  // The observations are sorted, so every bin is found by a binary
+  // search of its upper bound.
+  prev := 0
+  for i := range bins {
+    next := len(s.xs)
+    if i != n-1 {
+      next = sort.SearchFloat64s(s.xs, bins[i].Hi)
+    }
+    bins[i].Count = next - prev
+    prev = next
+  }
+  return bins
+}
+
+// SturgesBins returns the number of histogram bins for n observations
+// by Sturges' rule, log2(n) + 1.
+func SturgesBins(n int) int {
+  if n < 1 {
+    return 1
+  }
+  return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+// FreedmanDiaconisWidth returns the width of histogram bins by
+// the Freedman-Diaconis rule, 2*IQR/cbrt(n). It is zero if the
+// interquartile range is zero.
+func (s *Sample) FreedmanDiaconisWidth() float64 {
+  return 2 * s.IQR() / math.Cbrt(float64(len(s.xs)))
+}
+
+// BoxPlot is the summary drawn by a box-and-whisker plot. The whiskers
+// extend to the most extreme observations within Tukey's fences,
+// 1.5 IQR from the quartiles. The observations beyond the fences are
+// outliers.
+type BoxPlot struct {
+  Q1, Median, Q3 float64
+  Lower, Upper   float64 // ends of the whiskers
+  Outliers       []float64
+}
+
+// BoxPlot returns the box plot summary of the sample.
+func (s *Sample) BoxPlot() BoxPlot {
+  b := BoxPlot{
+    Q1:     s.Quantile(0.25),
+    Median: s.Median(),
+    Q3:     s.Quantile(0.75),
+  }
+  lo, hi := s.TukeyFences(1.5)
+
+  b.Lower, b.Upper = b.Q1, b.Q3
+  for _, x := range s.xs {
+    if x < lo || x > hi {
+      b.Outliers = append(b.Outliers, x)
+      continue
+    }
+    b.Lower = math.Min(b.Lower, x)
+    b.Upper = math.Max(b.Upper, x)
+  }
+  return b
+}
+
+// TukeyFences returns the bounds k interquartile ranges below the first
+// quartile and above the third one. Observations beyond them are
+// outliers for k = 1.5 and far outliers for k = 3.
+func (s *Sample) TukeyFences(k float64) (lo, hi float64) {
+  q1, q3 := s.Quantile(0.25), s.Quantile(0.75)
+  return q1 - k*(q3-q1), q3 + k*(q3-q1)
+}
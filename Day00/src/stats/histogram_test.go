@@ -0,0 +1,46 @@
+package stats
+
+import (
+  "slices"
+  "testing"
+)
+
+func TestHistogram(t *testing.T) {
+  s := NewSample([]float64{0, 1, 1, 2, 5, 9.5, 10})
+  tests := []struct {
+    name string
+    bins []Bin
+    want []Bin
+  }{
+    {"count", s.Histogram(2), []Bin{{0, 5, 4}, {5, 10, 3}}},
+    {"width", s.HistogramWidth(4), []Bin{{0, 4, 4}, {4, 8, 1}, {8, 12, 2}}},
+    {"constant", NewSample([]int{3, 3}).Histogram(5), []Bin{{3, 3, 2}}},
+    {"empty", NewSample([]int{}).Histogram(5), nil},
+    {"overflow", NewSample([]float64{-1e308, 0, 1e308}).Histogram(2),
+      []Bin{{-1e308, 0, 1}, {0, 1e308, 2}}},
+    {"infinite bins", NewSample([]float64{-1e308, 1e308}).HistogramWidth(1), nil},
+  }
+
+  for _, tt := range tests {
+    if !slices.Equal(tt.bins, tt.want) {
+      t.Errorf("%s: got %v, want %v", tt.name, tt.bins, tt.want)
+    }
+  }
+
+  if got := SturgesBins(100); got != 8 {
+    t.Errorf("SturgesBins(100) = %d, want 8", got)
+  }
+}
+
+func TestBoxPlot(t *testing.T) {
+  s := NewSample([]int{-40, 1, 2, 3, 4, 5, 6, 7, 8, 9, 30})
+  got := s.BoxPlot()
+  want := BoxPlot{Q1: 2.5, Median: 5, Q3: 7.5, Lower: 1, Upper: 9,
+    Outliers: []float64{-40, 30}}
+
+  if got.Q1 != want.Q1 || got.Median != want.Median || got.Q3 != want.Q3 ||
+    got.Lower != want.Lower || got.Upper != want.Upper ||
+    !slices.Equal(got.Outliers, want.Outliers) {
+    t.Errorf("BoxPlot() = %+v, want %+v", got, want)
+  }
+}
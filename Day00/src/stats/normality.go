@@ -0,0 +1,133 @@
+package stats
+
+import "math"
+
+// JarqueBera tests whether xs comes from a normal distribution by its
+// skewness and excess kurtosis. The p-value is by the asymptotic
+// chi-squared distribution with 2 degrees of freedom, which needs a few
+// hundred observations to be accurate.
+func JarqueBera[T Number](xs []T) TestResult {
+  s := NewSample(xs)
+  n := float64(s.Len())
+  skew, kurt := s.Skewness(), s.Kurtosis()
+  jb := n / 6 * (skew*skew + kurt*kurt/4)
+  return TestResult{Statistic: jb, DF: 2, P: math.Exp(-jb / 2)}
+}
+
+// AndersonDarling tests whether xs comes from a normal distribution of
+// unknown mean and variance. The statistic is A² adjusted for the sample
+// size, the p-value is by the approximation of D'Agostino and Stephens.
+func AndersonDarling[T Number](xs []T) TestResult {
+  s := NewSample(xs)
+  n := s.Len()
+  if n < 3 {
+    return TestResult{Statistic: math.NaN(), P: math.NaN()}
+  }
+  mu, sd := s.Mean(), math.Sqrt(s.Variance(1))
+
+  var acc accumulator
+  for i, x := range s.xs {
+    lo := NormalCDF((x - mu) / sd)
+    hi := NormalCDF((s.xs[n-1-i] - mu) / sd)
+    acc.Add(float64(2*i+1) * (math.Log(lo) + math.Log1p(-hi)))
+  }
+  fn := float64(n)
+  a2 := -fn - acc.Sum()/fn
+  a := a2 * (1 + 0.75/fn + 2.25/(fn*fn))
+
+  var p float64
+  switch {
+  case a >= 153.467: // the approximation starts growing past its minimum
+    p = 0
+  case a >= 0.6:
+    p = math.Exp(1.2937 - 5.709*a + 0.0186*a*a)
+  case a >= 0.34:
+    p = math.Exp(0.9177 - 4.279*a - 1.38*a*a)
+  case a >= 0.2:
+    p = 1 - math.Exp(-8.318+42.796*a-59.938*a*a)
+  default:
+    p = 1 - math.Exp(-13.436+101.14*a-223.73*a*a)
+  }
+  return TestResult{Statistic: a, P: math.Max(0, math.Min(p, 1))}
+}
+
+// ShapiroWilk tests whether xs comes from a normal distribution. The
+// statistic W and its p-value are by Royston's approximation (algorithm
+// AS R94), valid for 3 to 5000 observations; the result is NaN outside
+// of this range or if all the observations are equal.
+func ShapiroWilk[T Number](xs []T) TestResult {
+  s := NewSample(xs)
+  n := s.Len()
+  if n < 3 || n > 5000 || s.Range() == 0 {
+    return TestResult{Statistic: math.NaN(), P: math.NaN()}
+  }
+  fn := float64(n)
+
+  // The coefficients are antisymmetric, a[i] = -a[n-1-i].
+  a := make([]float64, n)
+  if n == 3 {
+    a[0], a[2] = -math.Sqrt2/2, math.Sqrt2/2
+  } else {
+    m := make([]float64, n)
+    var mm float64
+    for i := range m {
+      m[i] = NormalQuantile((float64(i+1) - 0.375) / (fn + 0.25))
+      mm += m[i] * m[i]
+    }
+    u := 1 / math.Sqrt(fn)
+    an := m[n-1]/math.Sqrt(mm) +
+      poly(u, 0, 0.221157, -0.147981, -2.071190, 4.434685, -2.706056)
+    first := 1
+    eps := (mm - 2*m[n-1]*m[n-1]) / (1 - 2*an*an)
+    a[n-1] = an
+    if n > 5 {
+      an1 := m[n-2]/math.Sqrt(mm) +
+        poly(u, 0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633)
+      first = 2
+      eps = (mm - 2*m[n-1]*m[n-1] - 2*m[n-2]*m[n-2]) /
+        (1 - 2*an*an - 2*an1*an1)
+      a[n-2] = an1
+    }
+    for i := first; i < n-first; i++ {
+      a[i] = m[i] / math.Sqrt(eps)
+    }
+    for i := 0; i < first; i++ {
+      a[i] = -a[n-1-i]
+    }
+  }
+
+  var num, den accumulator
+  mu := s.Mean()
+  for i, x := range s.xs {
+    num.Add(a[i] * x)
+    den.Add((x - mu) * (x - mu))
+  }
+  w := min(num.Sum()*num.Sum()/den.Sum(), 1)
+
+  var p float64
+  switch {
+  case n == 3:
+    p = 6 / math.Pi * (math.Asin(math.Sqrt(w)) - math.Asin(math.Sqrt(0.75)))
+  case n <= 11:
+    gamma := poly(fn, -2.273, 0.459)
+    mean := poly(fn, 0.544, -0.39978, 0.025054, -6.714e-4)
+    sd := math.Exp(poly(fn, 1.3822, -0.77857, 0.062767, -0.0020322))
+    p = 1 - NormalCDF((-math.Log(gamma-math.Log1p(-w))-mean)/sd)
+  default:
+    ln := math.Log(fn)
+    mean := poly(ln, -1.5861, -0.31082, -0.083751, 0.0038915)
+    sd := math.Exp(poly(ln, -0.4803, -0.082676, 0.0030302))
+    p = 1 - NormalCDF((math.Log1p(-w)-mean)/sd)
+  }
+  return TestResult{Statistic: w, P: math.Max(0, math.Min(p, 1))}
+}
+
+// poly evaluates the polynomial of the coefficients c, from the constant
+// term up, at x.
+func poly(x float64, c ...float64) float64 {
+  var y float64
+  for i := len(c) - 1; i >= 0; i-- {
+    y = y*x + c[i]
+  }
+  return y
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+  "math"
+  "testing"
+)
+
+// quantiles returns n evenly spaced quantiles of a distribution.
+func quantiles(n int, quantile func(p float64) float64) []float64 {
+  xs := make([]float64, n)
+  for i := range xs {
+    xs[i] = quantile((float64(i) + 0.5) / float64(n))
+  }
+  return xs
+}
+
+func TestNormality(t *testing.T) {
+  // Weights of 11 men in pounds from Shapiro and Wilk (1965); R's
+  // shapiro.test gives W = 0.78881, p = 0.006704.
+  men := []int{148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236}
+  if sw := ShapiroWilk(men); math.Abs(sw.Statistic-0.78881) > 1e-5 ||
+    math.Abs(sw.P-0.006704) > 1e-6 {
+    t.Errorf("ShapiroWilk(men) = %+v, want W 0.78881, p 0.006704", sw)
+  }
+
+  normal := quantiles(200, NormalQuantile)
+  expo := quantiles(200, func(p float64) float64 { return -math.Log1p(-p) })
+  for _, test := range []struct {
+    name string
+    fn   func([]float64) TestResult
+  }{
+    {"ShapiroWilk", ShapiroWilk[float64]},
+    {"AndersonDarling", AndersonDarling[float64]},
+    {"JarqueBera", JarqueBera[float64]},
+  } {
+    if p := test.fn(normal).P; !(p > 0.5) {
+      t.Errorf("%s(normal).P = %v, want > 0.5", test.name, p)
+    }
+    if p := test.fn(expo).P; !(p < 0.001) {
+      t.Errorf("%s(exponential).P = %v, want < 0.001", test.name, p)
+    }
+  }
+
+  if sw := ShapiroWilk([]int{1, 2, 3}); !almostEqual(sw.Statistic, 1) ||
+    !almostEqual(sw.P, 1) {
+    t.Errorf("ShapiroWilk(1, 2, 3) = %+v, want W 1, p 1", sw)
+  }
+  if sw := ShapiroWilk([]int{4, 4, 4, 4}); !math.IsNaN(sw.Statistic) {
+    t.Errorf("ShapiroWilk(constant) = %+v, want NaN", sw)
+  }
+}
+
+func TestFitDistributions(t *testing.T) {
+  for _, tt := range []struct {
+    want string
+    xs   []float64
+  }{
+    {"normal", quantiles(500, func(p float64) float64 {
+      return 50 + 10*NormalQuantile(p)
+    })},
+    {"lognormal", quantiles(500, func(p float64) float64 {
+      return math.Exp(NormalQuantile(p))
+    })},
+    {"exponential", quantiles(500, func(p float64) float64 {
+      return -math.Log1p(-p) / 4
+    })},
+    {"uniform", quantiles(500, func(p float64) float64 { return 3 + p })},
+  } {
+    fits := FitDistributions(tt.xs)
+    if len(fits) == 0 || fits[0].Name != tt.want {
+      t.Errorf("%s: best fit %+v", tt.want, fits)
+    }
+  }
+
+  f, ok := FitExponential([]float64{1, 2, 3})
+  if !ok || f.Params[0].Value != 0.5 ||
+    !almostEqual(f.LogLikelihood, 3*math.Log(0.5)-3) {
+    t.Errorf("FitExponential = %+v", f)
+  }
+  if _, ok := FitLogNormal([]float64{-1, 2}); ok {
+    t.Error("FitLogNormal of a negative sample succeeded")
+  }
+}
@@ -0,0 +1,94 @@
+package stats
+
+import "math"
+
+// OutlierRule decides which observations of a sample are outliers.
+type OutlierRule int
+
+const (
+  // Tukey marks observations more than k interquartile ranges below
+  // the first quartile or above the third one.
+  Tukey OutlierRule = iota
+  // ZScore marks observations more than k standard deviations from
+  // the mean.
+  ZScore
+  // ModifiedZScore marks observations whose modified z-score
+  // 0.6745*(x-median)/MAD by Iglewicz and Hoaglin exceeds k in absolute
+  // value. It is robust to the outliers themselves.
+  ModifiedZScore
+)
+
+// Outliers finds the outliers of xs by the rule with the threshold k.
+// It returns their indices in xs and their scores: z-scores, modified
+// z-scores, or for Tukey's rule the distance beyond the nearest quartile
+// in interquartile ranges.
+func Outliers(xs []float64, rule OutlierRule, k float64) (idx []int, scores []float64) {
+  s := NewSample(xs)
+  score := s.scorer(rule)
+  for i, x := range xs {
+    if z := score(x); math.Abs(z) > k {
+      idx = append(idx, i)
+      scores = append(scores, z)
+    }
+  }
+  return idx, scores
+}
+
+func (s *Sample) scorer(rule OutlierRule) func(x float64) float64 {
+  switch rule {
+  case ZScore:
+    avg, sd := s.Mean(), s.StdDev()
+    return func(x float64) float64 { return (x - avg) / sd }
+  case ModifiedZScore:
+    med, mad := s.Median(), s.MAD()
+    return func(x float64) float64 { return 0.6745 * (x - med) / mad }
+  }
+
+  q1, q3 := s.Quantile(0.25), s.Quantile(0.75)
+  return func(x float64) float64 {
+    switch {
+    case x < q1:
+      return (x - q1) / (q3 - q1)
+    case x > q3:
+      return (x - q3) / (q3 - q1)
+    }
+    return 0
+  }
+}
+
+// TrimmedMean returns the mean of the observations left after
+// removing the fraction p, 0 <= p < 0.5, of the smallest and of the
+// largest ones.
+func (s *Sample) TrimmedMean(p float64) float64 {
+  k, ok := s.cut(p)
+  if !ok {
+    return math.NaN()
+  }
+  return mean(s.xs[k : len(s.xs)-k])
+}
+
+// WinsorizedMean returns the mean of the sample after replacing the
+// fraction p, 0 <= p < 0.5, of the smallest and of the largest
+// observations with the nearest remaining ones.
+func (s *Sample) WinsorizedMean(p float64) float64 {
+  k, ok := s.cut(p)
+  if !ok {
+    return math.NaN()
+  }
+
+  n := len(s.xs)
+  var acc accumulator
+  for i := range s.xs {
+    acc.Add(s.xs[min(max(i, k), n-1-k)])
+  }
+  return acc.Sum() / float64(n)
+}
+
+// cut returns the number of observations in the fraction p of
+// the sample.
+func (s *Sample) cut(p float64) (int, bool) {
+  if len(s.xs) == 0 || !(p >= 0 && p < 0.5) {
+    return 0, false
+  }
+  return int(math.Floor(p * float64(len(s.xs)))), true
+}
@@ -0,0 +1,56 @@
+package stats
+
+import (
+  "math"
+  "slices"
+  "testing"
+)
+
+func TestOutliers(t *testing.T) {
+  xs := []float64{10, 11, 12, 11, 10, 95, 12, 11, 10, -60, 11, 12}
+  tests := []struct {
+    name string
+    rule OutlierRule
+    k    float64
+    want []int
+  }{
+    {"tukey", Tukey, 1.5, []int{5, 9}},
+    {"z-score", ZScore, 2, []int{5, 9}},
+    {"z-score masked", ZScore, 3, nil},
+    {"modified z-score", ModifiedZScore, 3.5, []int{5, 9}},
+  }
+
+  for _, tt := range tests {
+    idx, scores := Outliers(xs, tt.rule, tt.k)
+    if !slices.Equal(idx, tt.want) || len(scores) != len(idx) {
+      t.Errorf("%s: got %v %v, want %v", tt.name, idx, scores, tt.want)
+    }
+  }
+}
+
+func TestTrimmedMean(t *testing.T) {
+  s := NewSample([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 100})
+  tests := []struct {
+    p                   float64
+    trimmed, winsorized float64
+  }{
+    {0, 14.5, 14.5},
+    {0.1, 5.5, 5.5},
+    {0.2, 5.5, 5.5},
+    {0.5, math.NaN(), math.NaN()},
+  }
+
+  for _, tt := range tests {
+    if got := s.TrimmedMean(tt.p); !almostEqual(got, tt.trimmed) {
+      t.Errorf("TrimmedMean(%v) = %v, want %v", tt.p, got, tt.trimmed)
+    }
+    if got := s.WinsorizedMean(tt.p); !almostEqual(got, tt.winsorized) {
+      t.Errorf("WinsorizedMean(%v) = %v, want %v", tt.p, got, tt.winsorized)
+    }
+  }
+
+  s = NewSample([]int{1, 2, 3, 10})
+  if got := s.WinsorizedMean(0.25); got != 2.5 {
+    t.Errorf("WinsorizedMean(0.25) = %v, want 2.5", got)
+  }
+}
@@ -0,0 +1,298 @@
+package stats
+
+import (
+  "bufio"
+  "errors"
+  "fmt"
+  "io"
+  "math"
+  "strconv"
+  "strings"
+  "time"
+  "unicode"
+)
+
+// Reasons an input line is rejected.
+var (
+  ErrEmpty      = errors.New("empty")
+  ErrNotNumber  = errors.New("not a number")
+  ErrRange      = errors.New("out of range")
+  ErrFieldCount = errors.New("wrong number of values")
+  ErrTimestamp  = errors.New("not a timestamp")
+)
+
+// A ParseError reports an invalid line of input.
+type ParseError struct {
+  Line int    // 1-based line number
+  Text string // offending text
+  Err  error  // reason, one of the Err variables
+}
+
+func (e *ParseError) Error() string {
+  return fmt.Sprintf("line %d: %q: %v", e.Line, e.Text, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+  return e.Err
+}
+
+// Range is an inclusive interval of accepted input values.
+type Range struct {
+  Min, Max float64
+}
+
+// Unbounded accepts every finite number.
+var Unbounded = Range{math.Inf(-1), math.Inf(1)}
+
+// Contains reports whether x is a finite number within the range.
+func (rg Range) Contains(x float64) bool {
+  return x >= rg.Min && x <= rg.Max && !math.IsInf(x, 0)
+}
+
+// ParseFloat parses a number in decimal or scientific notation and
+// checks that it lies within rg. The error is ErrEmpty, ErrNotNumber
+// or ErrRange.
+func ParseFloat(s string, rg Range) (float64, error) {
+  if s == "" {
+    return 0, ErrEmpty
+  }
+  x, err := strconv.ParseFloat(s, 64)
+  if err != nil && !errors.Is(err, strconv.ErrRange) || math.IsNaN(x) {
+    return 0, ErrNotNumber
+  }
+  if err != nil || !rg.Contains(x) {
+    return 0, ErrRange
+  }
+  return x, nil
+}
+
+// Reader reads numbers separated by newlines.
+type Reader struct {
+  // Range restricts the accepted values.
+  Range Range
+  // Skip, if not nil, is called for every invalid line, which is then
+  // ignored. Otherwise reading stops at the first invalid line.
+  Skip func(*ParseError)
+}
+
+// fail handles an invalid line, returning the error to stop reading at.
+func (rd *Reader) fail(line int, text string, err error) error {
+  e := &ParseError{line, text, err}
+  if rd.Skip == nil {
+    return e
+  }
+  rd.Skip(e)
+  return nil
+}
+
+// ReadFloats reads numbers from r, one per line.
+func (rd *Reader) ReadFloats(r io.Reader) ([]float64, error) {
+  var nums []float64
+  err := rd.ScanFloats(r, func(num float64) {
+    nums = append(nums, num)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return nums, nil
+}
+
+// ReadNumbered reads numbers like ReadFloats and also returns
+// the line number of every number.
+func (rd *Reader) ReadNumbered(r io.Reader) (nums []float64, lines []int, err error) {
+  err = rd.scan(r, func(line int, num float64) {
+    nums = append(nums, num)
+    lines = append(lines, line)
+  })
+  if err != nil {
+    return nil, nil, err
+  }
+  return nums, lines, nil
+}
+
+// ScanFloats reads numbers like ReadFloats but passes each of them to
+// fn instead of storing them, so input of any size is read in constant
+// memory.
+func (rd *Reader) ScanFloats(r io.Reader, fn func(float64)) error {
+  return rd.scan(r, func(_ int, num float64) { fn(num) })
+}
+
+func (rd *Reader) scan(r io.Reader, fn func(line int, num float64)) error {
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    text := strings.TrimSpace(scanner.Text())
+    num, err := ParseFloat(text, rd.Range)
+    if err != nil {
+      if err = rd.fail(line, text, err); err != nil {
+        return err
+      }
+      continue
+    }
+    fn(line, num)
+  }
+  return scanner.Err()
+}
+
+// ReadTokens reads categorical data from r, a token per line. Tokens
+// are trimmed of surrounding whitespace; empty lines are invalid.
+func (rd *Reader) ReadTokens(r io.Reader) ([]string, error) {
+  var tokens []string
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    token := strings.TrimSpace(scanner.Text())
+    if token == "" {
+      if err := rd.fail(line, token, ErrEmpty); err != nil {
+        return nil, err
+      }
+      continue
+    }
+    tokens = append(tokens, token)
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, err
+  }
+  return tokens, nil
+}
+
+// ReadPairs reads points from r, one per line. The coordinates of
+// a point are separated by whitespace or a comma.
+func (rd *Reader) ReadPairs(r io.Reader) (xs, ys []float64, err error) {
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    point, text, err := rd.parseFields(scanner.Text(), 2)
+    if err != nil {
+      if err = rd.fail(line, text, err); err != nil {
+        return nil, nil, err
+      }
+      continue
+    }
+    xs = append(xs, point[0])
+    ys = append(ys, point[1])
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, nil, err
+  }
+
+  return xs, ys, nil
+}
+
+// ReadWeighted reads values with their weights from r, one pair per
+// line, separated by whitespace or a comma. Values are restricted to
+// the Range of the reader, weights to finite non-negative numbers.
+func (rd *Reader) ReadWeighted(r io.Reader) (values, weights []float64, err error) {
+  weight := Range{0, math.Inf(1)}
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    fields := splitFields(scanner.Text())
+    if len(fields) != 2 {
+      err = rd.fail(line, strings.TrimSpace(scanner.Text()), ErrFieldCount)
+    } else if x, perr := ParseFloat(fields[0], rd.Range); perr != nil {
+      err = rd.fail(line, fields[0], perr)
+    } else if w, perr := ParseFloat(fields[1], weight); perr != nil {
+      err = rd.fail(line, fields[1], perr)
+    } else {
+      values = append(values, x)
+      weights = append(weights, w)
+    }
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, nil, err
+  }
+
+  return values, weights, nil
+}
+
+// ReadLabeled reads labeled numbers from r, one per line. A line has
+// a label and a number separated by whitespace or a comma; key is
+// the index of the label, 0 or 1.
+func (rd *Reader) ReadLabeled(r io.Reader, key int) (labels []string, nums []float64, err error) {
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    fields := splitFields(scanner.Text())
+    if len(fields) != 2 {
+      err = rd.fail(line, strings.TrimSpace(scanner.Text()), ErrFieldCount)
+    } else if num, perr := ParseFloat(fields[1-key], rd.Range); perr != nil {
+      err = rd.fail(line, fields[1-key], perr)
+    } else {
+      labels = append(labels, fields[key])
+      nums = append(nums, num)
+    }
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, nil, err
+  }
+
+  return labels, nums, nil
+}
+
+// ScanTimed reads timestamped numbers from r, one per line, and passes
+// each of them to fn. A line has a timestamp and a number separated by
+// whitespace or a comma. The timestamp is in RFC 3339 format or
+// the number of seconds since the Unix epoch.
+func (rd *Reader) ScanTimed(r io.Reader, fn func(t time.Time, num float64)) error {
+  scanner := bufio.NewScanner(r)
+  for line := 1; scanner.Scan(); line++ {
+    var err error
+    fields := splitFields(scanner.Text())
+    if len(fields) != 2 {
+      err = rd.fail(line, strings.TrimSpace(scanner.Text()), ErrFieldCount)
+    } else if t, terr := ParseTimestamp(fields[0]); terr != nil {
+      err = rd.fail(line, fields[0], terr)
+    } else if num, perr := ParseFloat(fields[1], rd.Range); perr != nil {
+      err = rd.fail(line, fields[1], perr)
+    } else {
+      fn(t, num)
+    }
+    if err != nil {
+      return err
+    }
+  }
+  return scanner.Err()
+}
+
+// ParseTimestamp parses a time in RFC 3339 format or the number of
+// seconds since the Unix epoch. The error is ErrTimestamp.
+func ParseTimestamp(s string) (time.Time, error) {
+  if sec, err := strconv.ParseFloat(s, 64); err == nil {
+    if math.IsNaN(sec) || math.IsInf(sec, 0) {
+      return time.Time{}, ErrTimestamp
+    }
+    whole, frac := math.Modf(sec)
+    return time.Unix(int64(whole), int64(frac*1e9)), nil
+  }
+  t, err := time.Parse(time.RFC3339Nano, s)
+  if err != nil {
+    return time.Time{}, ErrTimestamp
+  }
+  return t, nil
+}
+
+func splitFields(line string) []string {
+  return strings.FieldsFunc(line, func(c rune) bool {
+    return c == ',' || unicode.IsSpace(c)
+  })
+}
+
+// parseFields parses a line of n numbers separated by whitespace or
+// commas. On failure it returns the offending text and the reason.
+func (rd *Reader) parseFields(line string, n int) ([]float64, string, error) {
+  fields := splitFields(line)
+  if len(fields) != n {
+    return nil, strings.TrimSpace(line), ErrFieldCount
+  }
+
+  nums := make([]float64, n)
+  for i, field := range fields {
+    var err error
+    if nums[i], err = ParseFloat(field, rd.Range); err != nil {
+      return nil, field, err
+    }
+  }
+  return nums, "", nil
+}
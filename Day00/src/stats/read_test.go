@@ -0,0 +1,158 @@
+package stats
+
+import (
+  "errors"
+  "slices"
+  "strings"
+  "testing"
+  "time"
+)
+
+func TestReadFloats(t *testing.T) {
+  bounded := Range{-100000, 100000}
+  tests := []struct {
+    name    string
+    in      string
+    rg      Range
+    want    []float64
+    wantErr *ParseError
+  }{
+    {"empty", "", bounded, nil, nil},
+    {"single", "42\n", bounded, []float64{42}, nil},
+    {"no trailing newline", "1\n2", bounded, []float64{1, 2}, nil},
+    {"spaces", " -3 \n4\n", bounded, []float64{-3, 4}, nil},
+    {"bounds", "100000\n-100000\n", bounded, []float64{100000, -100000}, nil},
+    {"fractions", "1.5\n-.25\n", bounded, []float64{1.5, -0.25}, nil},
+    {"scientific", "1e3\n2.5E-2\n", bounded, []float64{1000, 0.025}, nil},
+    {"unbounded", "1e300\n", Unbounded, []float64{1e300}, nil},
+    {"out of range", "1\n100001\n", bounded, nil,
+      &ParseError{2, "100001", ErrRange}},
+    {"custom range", "0\n1\n", Range{0, 0.5}, nil,
+      &ParseError{2, "1", ErrRange}},
+    {"overflow", "1e400\n", Unbounded, nil,
+      &ParseError{1, "1e400", ErrRange}},
+    {"infinity", "inf\n", Unbounded, nil,
+      &ParseError{1, "inf", ErrRange}},
+    {"nan", "NaN\n", Unbounded, nil,
+      &ParseError{1, "NaN", ErrNotNumber}},
+    {"letters", "1\n2\n 3abc\n", bounded, nil,
+      &ParseError{3, "3abc", ErrNotNumber}},
+    {"empty line", "1\n\n2\n", bounded, nil,
+      &ParseError{2, "", ErrEmpty}},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      rd := Reader{Range: tt.rg}
+      got, err := rd.ReadFloats(strings.NewReader(tt.in))
+      if tt.wantErr == nil && err != nil {
+        t.Fatalf("unexpected error %v", err)
+      }
+      if tt.wantErr != nil {
+        var e *ParseError
+        if !errors.As(err, &e) || *e != *tt.wantErr {
+          t.Fatalf("err = %v, want %v", err, tt.wantErr)
+        }
+      }
+      if !slices.Equal(got, tt.want) {
+        t.Errorf("got %v, want %v", got, tt.want)
+      }
+    })
+  }
+}
+
+func TestReaderSkip(t *testing.T) {
+  var skipped []ParseError
+  rd := Reader{
+    Range: Range{0, 10},
+    Skip:  func(e *ParseError) { skipped = append(skipped, *e) },
+  }
+
+  got, err := rd.ReadFloats(strings.NewReader("1\nx\n\n2\n11\n3\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !slices.Equal(got, []float64{1, 2, 3}) {
+    t.Errorf("got %v, want [1 2 3]", got)
+  }
+  want := []ParseError{
+    {2, "x", ErrNotNumber},
+    {3, "", ErrEmpty},
+    {5, "11", ErrRange},
+  }
+  if !slices.Equal(skipped, want) {
+    t.Errorf("skipped %v, want %v", skipped, want)
+  }
+}
+
+func TestReadPairs(t *testing.T) {
+  rd := Reader{Range: Unbounded}
+  xs, ys, err := rd.ReadPairs(strings.NewReader("1,2\n3 4\n5\t, 6\n"))
+  if err != nil || !slices.Equal(xs, []float64{1, 3, 5}) ||
+    !slices.Equal(ys, []float64{2, 4, 6}) {
+    t.Errorf("got %v %v %v", xs, ys, err)
+  }
+
+  for _, tt := range []struct {
+    in   string
+    want error
+  }{
+    {"1\n", ErrFieldCount},
+    {"1 2 3\n", ErrFieldCount},
+    {"1 a\n", ErrNotNumber},
+  } {
+    if _, _, err := rd.ReadPairs(strings.NewReader(tt.in)); !errors.Is(err, tt.want) {
+      t.Errorf("%q: err = %v, want %v", tt.in, err, tt.want)
+    }
+  }
+}
+
+func TestReadNumbered(t *testing.T) {
+  rd := Reader{Range: Unbounded, Skip: func(*ParseError) {}}
+  nums, lines, err := rd.ReadNumbered(strings.NewReader("1\nx\n2\n\n3\n"))
+  if err != nil || !slices.Equal(nums, []float64{1, 2, 3}) ||
+    !slices.Equal(lines, []int{1, 3, 5}) {
+    t.Errorf("got %v %v %v", nums, lines, err)
+  }
+}
+
+func TestReadLabeled(t *testing.T) {
+  rd := Reader{Range: Unbounded}
+  labels, nums, err := rd.ReadLabeled(strings.NewReader("/a 1\n/b,2\n"), 0)
+  if err != nil || !slices.Equal(labels, []string{"/a", "/b"}) ||
+    !slices.Equal(nums, []float64{1, 2}) {
+    t.Errorf("got %v %v %v", labels, nums, err)
+  }
+
+  labels, nums, err = rd.ReadLabeled(strings.NewReader("1 /a\n"), 1)
+  if err != nil || !slices.Equal(labels, []string{"/a"}) ||
+    !slices.Equal(nums, []float64{1}) {
+    t.Errorf("key 1: got %v %v %v", labels, nums, err)
+  }
+
+  _, _, err = rd.ReadLabeled(strings.NewReader("/a 1\n/b x\n"), 0)
+  want := &ParseError{2, "x", ErrNotNumber}
+  if e, ok := err.(*ParseError); !ok || *e != *want {
+    t.Errorf("err = %v, want %v", err, want)
+  }
+}
+
+func TestScanTimed(t *testing.T) {
+  var times []time.Time
+  var nums []float64
+  rd := Reader{Range: Unbounded}
+  in := "1700000000 1\n2023-11-14T22:13:21.5Z,2\n"
+  err := rd.ScanTimed(strings.NewReader(in), func(t time.Time, num float64) {
+    times = append(times, t)
+    nums = append(nums, num)
+  })
+  if err != nil || !slices.Equal(nums, []float64{1, 2}) ||
+    times[1].Sub(times[0]) != 1500*time.Millisecond {
+    t.Errorf("got %v %v %v", times, nums, err)
+  }
+
+  err = rd.ScanTimed(strings.NewReader("yesterday 1\n"), func(time.Time, float64) {})
+  if !errors.Is(err, ErrTimestamp) {
+    t.Errorf("err = %v, want %v", err, ErrTimestamp)
+  }
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+  "math"
+  "math/bits"
+  "slices"
+)
+
+// Select rearranges xs so that xs[k] is the element that would be at
+// index k if xs were sorted, with no greater elements before it and no
+// smaller ones after it, and returns xs[k]. It takes linear time on
+// average, falling back to sorting on adversarial input.
+func Select(xs []float64, k int) float64 {
+  lo, hi := 0, len(xs)
+  for depth := 2 * bits.Len(uint(len(xs))); hi-lo > 16 && depth > 0; depth-- {
+    lt, gt := partition(xs[lo:hi])
+    lt, gt = lt+lo, gt+lo
+    switch {
+    case k < lt:
+      hi = lt
+    case k >= gt:
+      lo = gt
+    default:
+      return xs[k]
+    }
+  }
+  slices.Sort(xs[lo:hi])
+  return xs[k]
+}
+
+// partition rearranges xs around the median of its first, middle and
+// last elements into elements less than, equal to and greater than
+// the pivot, and returns the bounds [lt, gt) of the equal ones.
+func partition(xs []float64) (lt, gt int) {
+  a, b, c := xs[0], xs[len(xs)/2], xs[len(xs)-1]
+  pivot := max(min(a, b), min(max(a, b), c))
+
+  lt, gt = 0, len(xs)
+  for i := 0; i < gt; {
+    switch {
+    case xs[i] < pivot:
+      xs[lt], xs[i] = xs[i], xs[lt]
+      lt++
+      i++
+    case xs[i] > pivot:
+      gt--
+      xs[gt], xs[i] = xs[i], xs[gt]
+    default:
+      i++
+    }
+  }
+  return lt, gt
+}
+
+// QuantileSelect returns the p-quantile of xs like Sample.Quantile,
+// selecting the closest ranks instead of sorting. It reorders xs.
+func QuantileSelect(xs []float64, p float64) float64 {
+  n := len(xs)
+  if n == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+
+  h := p * float64(n-1)
+  lo := int(math.Floor(h))
+  if lo >= n-1 {
+    return Select(xs, n-1)
+  }
+  x := Select(xs, lo)
+  return x + (h-float64(lo))*(slices.Min(xs[lo+1:])-x)
+}
+
+// MedianSelect returns the median of xs like Sample.Median without
+// sorting. It reorders xs.
+func MedianSelect(xs []float64) float64 {
+  return QuantileSelect(xs, 0.5)
+}
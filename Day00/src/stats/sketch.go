@@ -0,0 +1,130 @@
+package stats
+
+import (
+  "math"
+  "sort"
+)
+
+// QuantileSketch estimates quantiles of a stream in bounded memory
+// using the Greenwald-Khanna algorithm. For a sketch built with error
+// eps, the rank of every estimate differs from the requested rank by at
+// most eps*n, where n is the number of observations. The sketch keeps
+// O(log(eps*n)/eps) observations.
+type QuantileSketch struct {
+  eps     float64
+  n       int
+  tuples  []gkTuple
+  inserts int
+}
+
+type gkTuple struct {
+  v float64
+  // g is the difference between the lowest possible rank of this tuple
+  // and the previous one, delta is the uncertainty of the rank.
+  g, delta int
+}
+
+// NewQuantileSketch returns an empty sketch with the rank error eps,
+// 0 < eps < 1.
+func NewQuantileSketch(eps float64) *QuantileSketch {
+  return &QuantileSketch{eps: eps}
+}
+
+// Add adds an observation.
+func (q *QuantileSketch) Add(x float64) {
+  i := sort.Search(len(q.tuples), func(i int) bool {
+    return q.tuples[i].v > x
+  })
+
+  delta := 0
+  if i != 0 && i != len(q.tuples) {
+    delta = int(math.Floor(2 * q.eps * float64(q.n)))
+  }
+  q.tuples = append(q.tuples, gkTuple{})
+  copy(q.tuples[i+1:], q.tuples[i:])
+  q.tuples[i] = gkTuple{x, 1, delta}
+  q.n++
+
+  q.inserts++
+  if float64(q.inserts) >= 1/(2*q.eps) {
+    q.compress()
+    q.inserts = 0
+  }
+}
+
+func (q *QuantileSketch) compress() {
+  limit := int(math.Floor(2 * q.eps * float64(q.n)))
+  for i := len(q.tuples) - 2; i >= 1; i-- {
+    t, next := q.tuples[i], &q.tuples[i+1]
+    if t.g+next.g+next.delta <= limit {
+      next.g += t.g
+      q.tuples = append(q.tuples[:i], q.tuples[i+1:]...)
+    }
+  }
+}
+
+// Len returns the number of observations added.
+func (q *QuantileSketch) Len() int {
+  return q.n
+}
+
+// Epsilon returns the rank error of the sketch as a fraction of Len.
+func (q *QuantileSketch) Epsilon() float64 {
+  return q.eps
+}
+
+// Quantile returns an observation whose rank is within Epsilon()*Len()
+// of the rank p(n-1) interpolated by Sample.Quantile, 0 <= p <= 1.
+// It lies within QuantileBounds(p).
+func (q *QuantileSketch) Quantile(p float64) float64 {
+  if q.n == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+
+  rank := int(math.Round(p*float64(q.n-1))) + 1
+  bound := float64(rank) + q.eps*float64(q.n)
+  v := q.tuples[len(q.tuples)-1].v
+  rmin := 0
+  for i, t := range q.tuples {
+    rmin += t.g
+    if float64(rmin+t.delta) > bound && i > 0 {
+      v = q.tuples[i-1].v
+      break
+    }
+  }
+  lo, hi := q.QuantileBounds(p)
+  return min(max(v, lo), hi)
+}
+
+// QuantileBounds returns an interval guaranteed to contain the exact
+// p-quantile of the stream, as interpolated by Sample.Quantile between
+// the observations at the 0-based ranks floor(p(n-1)) and ceil(p(n-1)).
+func (q *QuantileSketch) QuantileBounds(p float64) (lo, hi float64) {
+  if q.n == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN(), math.NaN()
+  }
+
+  h := p * float64(q.n-1)
+  lo, _ = q.rankBounds(int(math.Floor(h)) + 1)
+  _, hi = q.rankBounds(int(math.Ceil(h)) + 1)
+  return lo, hi
+}
+
+// rankBounds returns kept observations below and above the observation
+// of the 1-based rank r. Every tuple lies between its lowest possible
+// rank, the sum of g up to it, and that plus delta. The smallest and
+// the largest observations are always kept exactly.
+func (q *QuantileSketch) rankBounds(r int) (lo, hi float64) {
+  lo, hi = q.tuples[0].v, q.tuples[len(q.tuples)-1].v
+  rmin, above := 0, false
+  for _, t := range q.tuples {
+    rmin += t.g
+    if rmin+t.delta <= r {
+      lo = t.v
+    }
+    if rmin >= r && !above {
+      hi, above = t.v, true
+    }
+  }
+  return lo, hi
+}
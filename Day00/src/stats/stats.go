@@ -0,0 +1,257 @@
+// Package stats computes descriptive statistics of numeric samples.
+//
+// Every function accepts integers as well as floating-point numbers.
+// Statistics of an empty sample are NaN.
+package stats
+
+import (
+  "math"
+  "slices"
+)
+
+// Number is the set of types a sample can be made of.
+type Number interface {
+  ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+    ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+    ~float32 | ~float64
+}
+
+// Sample is a sorted set of observations. Order statistics (median,
+// quantiles, mode) of a Sample are computed without sorting again.
+type Sample struct {
+  xs []float64
+}
+
+// NewSample copies values into a new sorted Sample.
+func NewSample[T Number](values []T) *Sample {
+  xs := toFloats(values)
+  slices.Sort(xs)
+  return &Sample{xs}
+}
+
+// Len returns the number of observations.
+func (s *Sample) Len() int {
+  return len(s.xs)
+}
+
+// Values returns the observations in ascending order.
+// The returned slice must not be modified.
+func (s *Sample) Values() []float64 {
+  return s.xs
+}
+
+// Mean returns the arithmetic mean.
+func (s *Sample) Mean() float64 {
+  return mean(s.xs)
+}
+
+// Median returns the middle observation if their count is odd,
+// and the average of the two middle ones otherwise.
+func (s *Sample) Median() float64 {
+  n := len(s.xs)
+  if n == 0 {
+    return math.NaN()
+  }
+  if n%2 != 0 {
+    return s.xs[n/2]
+  }
+  return (s.xs[n/2-1] + s.xs[n/2]) / 2.0
+}
+
+// Mode returns the most frequent observation. If there are several,
+// the smallest one is returned.
+func (s *Sample) Mode() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.Modes()[0].Value
+}
+
+// StdDev returns the population standard deviation.
+func (s *Sample) StdDev() float64 {
+  return math.Sqrt(variance(s.xs))
+}
+
+// Variance returns the sum of squared deviations from the mean divided
+// by n-ddof. ddof is 0 for the population variance and 1 for the
+// unbiased (Bessel-corrected) sample variance.
+func (s *Sample) Variance(ddof int) float64 {
+  return withDDOF(variance(s.xs), len(s.xs), ddof)
+}
+
+// StdErr returns the standard error of the mean, the standard deviation
+// with the given ddof divided by the square root of the sample size.
+func (s *Sample) StdErr(ddof int) float64 {
+  return math.Sqrt(s.Variance(ddof) / float64(len(s.xs)))
+}
+
+// Min returns the smallest observation.
+func (s *Sample) Min() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.xs[0]
+}
+
+// Max returns the largest observation.
+func (s *Sample) Max() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.xs[len(s.xs)-1]
+}
+
+// Quantile returns the p-quantile, 0 <= p <= 1, linearly interpolated
+// between the closest ranks. Quantile(0.5) is the median.
+func (s *Sample) Quantile(p float64) float64 {
+  n := len(s.xs)
+  if n == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+
+  h := p * float64(n-1)
+  lo := int(math.Floor(h))
+  if lo >= n-1 {
+    return s.xs[n-1]
+  }
+  return s.xs[lo] + (h-float64(lo))*(s.xs[lo+1]-s.xs[lo])
+}
+
+// Mean returns the arithmetic mean of xs.
+func Mean[T Number](xs []T) float64 {
+  return mean(toFloats(xs))
+}
+
+// Median returns the median of xs.
+func Median[T Number](xs []T) float64 {
+  return NewSample(xs).Median()
+}
+
+// Mode returns the most frequent value of xs, the smallest one on ties.
+// It returns the zero value if xs is empty.
+func Mode[T Number](xs []T) T {
+  if len(xs) == 0 {
+    return 0
+  }
+  return T(NewSample(xs).Mode())
+}
+
+// StdDev returns the population standard deviation of xs.
+func StdDev[T Number](xs []T) float64 {
+  return math.Sqrt(variance(toFloats(xs)))
+}
+
+// Min returns the smallest value of xs. It returns the zero value
+// if xs is empty.
+func Min[T Number](xs []T) T {
+  if len(xs) == 0 {
+    return 0
+  }
+  return slices.Min(xs)
+}
+
+// Max returns the largest value of xs. It returns the zero value
+// if xs is empty.
+func Max[T Number](xs []T) T {
+  if len(xs) == 0 {
+    return 0
+  }
+  return slices.Max(xs)
+}
+
+// Quantile returns the p-quantile of xs, see Sample.Quantile.
+func Quantile[T Number](xs []T, p float64) float64 {
+  return NewSample(xs).Quantile(p)
+}
+
+func toFloats[T Number](values []T) []float64 {
+  xs := make([]float64, len(values))
+  for i, v := range values {
+    xs[i] = float64(v)
+  }
+  return xs
+}
+
+func mean(xs []float64) float64 {
+  if len(xs) == 0 {
+    return math.NaN()
+  }
+
+  return sum(xs) / float64(len(xs))
+}
+
+// withDDOF converts the population variance of n observations to
+// the variance with ddof delta degrees of freedom.
+func withDDOF(v float64, n, ddof int) float64 {
+  if n <= ddof {
+    return math.NaN()
+  }
+  return v * float64(n) / float64(n-ddof)
+}
+
+func variance(xs []float64) float64 {
+  return centralMoment(xs, 2)
+}
+
+// Sum returns the sum of the observations.
+func (s *Sample) Sum() float64 {
+  return sum(s.xs)
+}
+
+// Range returns the difference between the largest and the smallest
+// observations.
+func (s *Sample) Range() float64 {
+  return s.Max() - s.Min()
+}
+
+// IQR returns the interquartile range, the difference between the
+// third and the first quartiles.
+func (s *Sample) IQR() float64 {
+  return s.Quantile(0.75) - s.Quantile(0.25)
+}
+
+// MAD returns the median absolute deviation from the median.
+func (s *Sample) MAD() float64 {
+  med := s.Median()
+  dev := make([]float64, len(s.xs))
+  for i, x := range s.xs {
+    dev[i] = math.Abs(x - med)
+  }
+  slices.Sort(dev)
+  return (&Sample{dev}).Median()
+}
+
+// CV returns the coefficient of variation, the ratio of the standard
+// deviation to the mean.
+func (s *Sample) CV() float64 {
+  return s.StdDev() / s.Mean()
+}
+
+// Skewness returns the population skewness, the third central moment
+// divided by the cubed standard deviation.
+func (s *Sample) Skewness() float64 {
+  m2, m3 := centralMoment(s.xs, 2), centralMoment(s.xs, 3)
+  return m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis returns the population excess kurtosis, the fourth central
+// moment divided by the squared variance, minus 3 so that the normal
+// distribution has zero kurtosis.
+func (s *Sample) Kurtosis() float64 {
+  m2, m4 := centralMoment(s.xs, 2), centralMoment(s.xs, 4)
+  return m4/(m2*m2) - 3
+}
+
+func centralMoment(xs []float64, k int) float64 {
+  avg := mean(xs)
+
+  var acc accumulator
+  for _, x := range xs {
+    d, p := x-avg, x-avg
+    for i := 1; i < k; i++ {
+      p *= d
+    }
+    acc.Add(p)
+  }
+  return acc.Sum() / float64(len(xs))
+}
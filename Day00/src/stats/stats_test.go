@@ -0,0 +1,219 @@
+package stats
+
+import (
+  "math"
+  "testing"
+)
+
+const eps = 1e-9
+
+func almostEqual(a, b float64) bool {
+  if math.IsNaN(a) || math.IsNaN(b) {
+    return math.IsNaN(a) && math.IsNaN(b)
+  }
+  return math.Abs(a-b) <= eps*math.Max(1, math.Abs(b))
+}
+
+func TestSample(t *testing.T) {
+  nan := math.NaN()
+  tests := []struct {
+    name                   string
+    in                     []int
+    mean, median, mode, sd float64
+    min, max               float64
+  }{
+    {"empty", nil, nan, nan, nan, nan, nan, nan},
+    {"single", []int{7}, 7, 7, 7, 0, 7, 7},
+    {"odd", []int{3, 1, 2}, 2, 2, 1, math.Sqrt(2.0 / 3.0), 1, 3},
+    {"even", []int{4, 1, 3, 2}, 2.5, 2.5, 1, math.Sqrt(1.25), 1, 4},
+    {"mode tie takes smallest", []int{5, 5, -2, -2, 9}, 3, 5, -2,
+      math.Sqrt(18.8), -2, 9},
+    {"negative", []int{-100000, 100000, 0}, 0, 0, -100000,
+      math.Sqrt(2e10 / 3), -100000, 100000},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s := NewSample(tt.in)
+      for _, got := range []struct {
+        metric    string
+        got, want float64
+      }{
+        {"Mean", s.Mean(), tt.mean},
+        {"Median", s.Median(), tt.median},
+        {"Mode", s.Mode(), tt.mode},
+        {"StdDev", s.StdDev(), tt.sd},
+        {"Min", s.Min(), tt.min},
+        {"Max", s.Max(), tt.max},
+      } {
+        if !almostEqual(got.got, got.want) {
+          t.Errorf("%s() = %v, want %v", got.metric, got.got, got.want)
+        }
+      }
+    })
+  }
+}
+
+func TestQuantile(t *testing.T) {
+  xs := []float64{10, 0, 30, 20, 40}
+  tests := []struct {
+    p, want float64
+  }{
+    {0, 0},
+    {0.25, 10},
+    {0.5, 20},
+    {0.6, 24},
+    {0.9, 36},
+    {1, 40},
+    {-0.1, math.NaN()},
+    {1.1, math.NaN()},
+  }
+
+  for _, tt := range tests {
+    if got := Quantile(xs, tt.p); !almostEqual(got, tt.want) {
+      t.Errorf("Quantile(%v) = %v, want %v", tt.p, got, tt.want)
+    }
+  }
+}
+
+func TestGeneric(t *testing.T) {
+  ints := []int{2, 4, 4, 4, 5, 5, 7, 9}
+  floats := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+  if got := Mean(ints); got != 5 {
+    t.Errorf("Mean(ints) = %v, want 5", got)
+  }
+  if got := StdDev(floats); got != 2 {
+    t.Errorf("StdDev(floats) = %v, want 2", got)
+  }
+  if got := Median(ints); got != 4.5 {
+    t.Errorf("Median(ints) = %v, want 4.5", got)
+  }
+  if got := Mode(ints); got != 4 {
+    t.Errorf("Mode(ints) = %v, want 4", got)
+  }
+  if got := Min(floats); got != 2 {
+    t.Errorf("Min(floats) = %v, want 2", got)
+  }
+  if got := Max(ints); got != 9 {
+    t.Errorf("Max(ints) = %v, want 9", got)
+  }
+  if got := Mode([]int(nil)); got != 0 {
+    t.Errorf("Mode(nil) = %v, want 0", got)
+  }
+}
+
+func TestModes(t *testing.T) {
+  tests := []struct {
+    name   string
+    in     []int
+    values []float64
+    count  int
+  }{
+    {"empty", nil, nil, 0},
+    {"unimodal", []int{3, 1, 3, 2}, []float64{3}, 2},
+    {"bimodal", []int{9, 4, 9, 4, 1}, []float64{4, 9}, 2},
+    {"all distinct", []int{3, 2, 1}, []float64{1, 2, 3}, 1},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      modes := NewSample(tt.in).Modes()
+      if len(modes) != len(tt.values) {
+        t.Fatalf("Modes() = %v, want values %v", modes, tt.values)
+      }
+      for i, m := range modes {
+        if m.Value != tt.values[i] || m.Count != tt.count {
+          t.Errorf("Modes()[%d] = %v, want %v (%d)", i, m, tt.values[i], tt.count)
+        }
+      }
+    })
+  }
+}
+
+func TestModeDeterministic(t *testing.T) {
+  in := []int{7, 3, 5, 3, 7, 5, 11, 11}
+  for i := 0; i < 100; i++ {
+    if got := Mode(in); got != 3 {
+      t.Fatalf("Mode() = %d, want 3", got)
+    }
+  }
+}
+
+func TestFrequencies(t *testing.T) {
+  got := NewSample([]int{2, 1, 2, 5}).Frequencies()
+  want := []Frequency{
+    {1, 1, 1, 0.25, 0.25},
+    {2, 2, 3, 0.5, 0.75},
+    {5, 1, 4, 0.25, 1},
+  }
+  if len(got) != len(want) {
+    t.Fatalf("Frequencies() = %v, want %v", got, want)
+  }
+  for i := range want {
+    if got[i] != want[i] {
+      t.Errorf("Frequencies()[%d] = %v, want %v", i, got[i], want[i])
+    }
+  }
+}
+
+func TestCompensatedMean(t *testing.T) {
+  tenths := make([]float64, 1000000)
+  for i := range tenths {
+    tenths[i] = 0.1
+  }
+
+  tests := []struct {
+    name string
+    in   []float64
+    want float64
+  }{
+    {"cancellation", []float64{1, 1e100, 1, -1e100}, 0.5},
+    {"tenths", tenths, 0.1},
+  }
+
+  for _, tt := range tests {
+    if got := Mean(tt.in); got != tt.want {
+      t.Errorf("%s: Mean() = %v, want %v", tt.name, got, tt.want)
+    }
+  }
+}
+
+func TestDescriptive(t *testing.T) {
+  s := NewSample([]int{5, 2, 4, 9, 4, 7, 4, 5})
+  for _, tt := range []struct {
+    metric    string
+    got, want float64
+  }{
+    {"Sum", s.Sum(), 40},
+    {"Range", s.Range(), 7},
+    {"IQR", s.IQR(), 1.5},
+    {"MAD", s.MAD(), 0.5},
+    {"CV", s.CV(), 0.4},
+    {"Skewness", s.Skewness(), 0.65625},
+    {"Kurtosis", s.Kurtosis(), -0.21875},
+  } {
+    if !almostEqual(tt.got, tt.want) {
+      t.Errorf("%s() = %v, want %v", tt.metric, tt.got, tt.want)
+    }
+  }
+}
+
+func TestVariance(t *testing.T) {
+  s := NewSample([]int{2, 4, 4, 4, 5, 5, 7, 9})
+  for _, tt := range []struct {
+    ddof         int
+    variance, se float64
+  }{
+    {0, 4, 1 / math.Sqrt(2)},
+    {1, 32.0 / 7, math.Sqrt(32.0 / 7 / 8)},
+    {8, math.NaN(), math.NaN()},
+  } {
+    if got := s.Variance(tt.ddof); !almostEqual(got, tt.variance) {
+      t.Errorf("Variance(%d) = %v, want %v", tt.ddof, got, tt.variance)
+    }
+    if got := s.StdErr(tt.ddof); !almostEqual(got, tt.se) {
+      t.Errorf("StdErr(%d) = %v, want %v", tt.ddof, got, tt.se)
+    }
+  }
+}
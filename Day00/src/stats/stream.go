@@ -0,0 +1,128 @@
+package stats
+
+import "math"
+
+// Moments accumulates the count, sum and central moments of a stream
+// of observations in constant memory using Welford's online algorithm,
+// extended to the third and fourth moments by Terriberry.
+// The zero value is an empty accumulator ready to use.
+type Moments struct {
+  n          int
+  sum        accumulator
+  mean       float64
+  m2, m3, m4 float64
+  min, max   float64
+}
+
+// Add adds an observation.
+func (m *Moments) Add(x float64) {
+  if m.n == 0 || x < m.min {
+    m.min = x
+  }
+  if m.n == 0 || x > m.max {
+    m.max = x
+  }
+  m.sum.Add(x)
+
+  n1 := float64(m.n)
+  m.n++
+  n := float64(m.n)
+  d := x - m.mean
+  dn := d / n
+  dn2 := dn * dn
+  term := d * dn * n1
+
+  m.mean += dn
+  m.m4 += term*dn2*(n*n-3*n+3) + 6*dn2*m.m2 - 4*dn*m.m3
+  m.m3 += term*dn*(n-2) - 3*dn*m.m2
+  m.m2 += term
+}
+
+// Len returns the number of observations added.
+func (m *Moments) Len() int {
+  return m.n
+}
+
+// Sum returns the sum of the observations.
+func (m *Moments) Sum() float64 {
+  return m.sum.Sum()
+}
+
+// Mean returns the arithmetic mean.
+func (m *Moments) Mean() float64 {
+  if m.n == 0 {
+    return math.NaN()
+  }
+  return m.mean
+}
+
+// StdDev returns the population standard deviation.
+func (m *Moments) StdDev() float64 {
+  if m.n == 0 {
+    return math.NaN()
+  }
+  return math.Sqrt(m.m2 / float64(m.n))
+}
+
+// Variance returns the variance with ddof delta degrees of freedom,
+// see Sample.Variance.
+func (m *Moments) Variance(ddof int) float64 {
+  if m.n == 0 {
+    return math.NaN()
+  }
+  return withDDOF(m.m2/float64(m.n), m.n, ddof)
+}
+
+// Skewness returns the population skewness.
+func (m *Moments) Skewness() float64 {
+  return math.Sqrt(float64(m.n)) * m.m3 / math.Pow(m.m2, 1.5)
+}
+
+// Kurtosis returns the population excess kurtosis.
+func (m *Moments) Kurtosis() float64 {
+  return float64(m.n)*m.m4/(m.m2*m.m2) - 3
+}
+
+// Min returns the smallest observation.
+func (m *Moments) Min() float64 {
+  if m.n == 0 {
+    return math.NaN()
+  }
+  return m.min
+}
+
+// Max returns the largest observation.
+func (m *Moments) Max() float64 {
+  if m.n == 0 {
+    return math.NaN()
+  }
+  return m.max
+}
+
+// Merge adds the observations accumulated by o, as if they were added
+// one by one, using the pairwise update of Chan et al. and Pébay.
+func (m *Moments) Merge(o *Moments) {
+  if o.n == 0 {
+    return
+  }
+  if m.n == 0 {
+    *m = *o
+    return
+  }
+
+  m.min, m.max = min(m.min, o.min), max(m.max, o.max)
+  m.sum.Add(o.sum.sum)
+  m.sum.Add(o.sum.c)
+
+  na, nb := float64(m.n), float64(o.n)
+  n := na + nb
+  d := o.mean - m.mean
+  dn := d / n
+  m4 := m.m4 + o.m4 + d*dn*dn*dn*na*nb*(na*na-na*nb+nb*nb) +
+    6*dn*dn*(na*na*o.m2+nb*nb*m.m2) + 4*dn*(na*o.m3-nb*m.m3)
+  m3 := m.m3 + o.m3 + d*dn*dn*na*nb*(na-nb) + 3*dn*(na*o.m2-nb*m.m2)
+  m.m2 += o.m2 + d*dn*na*nb
+  m.m3, m.m4 = m3, m4
+  m.mean += dn * nb
+  m.n += o.n
+}
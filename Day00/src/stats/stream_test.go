@@ -0,0 +1,146 @@
+package stats
+
+import (
+  "math"
+  "math/rand"
+  "testing"
+)
+
+func TestMoments(t *testing.T) {
+  tests := [][]float64{
+    nil,
+    {5},
+    {2, 4, 4, 4, 5, 5, 7, 9},
+    {1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+    {-3, 0.5, 12, 7.25, 1, 1, 40},
+  }
+
+  for _, xs := range tests {
+    var m Moments
+    for _, x := range xs {
+      m.Add(x)
+    }
+    s := NewSample(xs)
+    if m.Len() != s.Len() ||
+      !almostEqual(m.Mean(), s.Mean()) ||
+      !almostEqual(m.StdDev(), s.StdDev()) ||
+      !almostEqual(m.Min(), s.Min()) ||
+      !almostEqual(m.Max(), s.Max()) ||
+      !almostEqual(m.Sum(), s.Sum()) ||
+      !almostEqual(m.Variance(1), s.Variance(1)) ||
+      len(xs) > 1 && (!almostEqual(m.Skewness(), s.Skewness()) ||
+        !almostEqual(m.Kurtosis(), s.Kurtosis())) {
+      t.Errorf("%v: got n=%d mean=%v sd=%v min=%v max=%v", xs,
+        m.Len(), m.Mean(), m.StdDev(), m.Min(), m.Max())
+    }
+  }
+}
+
+func TestMomentsMerge(t *testing.T) {
+  xs := []float64{-3, 0.5, 12, 7.25, 1, 1, 40, 1e3}
+  var whole Moments
+  for _, x := range xs {
+    whole.Add(x)
+  }
+
+  for split := 0; split <= len(xs); split++ {
+    var a, b Moments
+    for _, x := range xs[:split] {
+      a.Add(x)
+    }
+    for _, x := range xs[split:] {
+      b.Add(x)
+    }
+    a.Merge(&b)
+    if a.Len() != whole.Len() ||
+      !almostEqual(a.Mean(), whole.Mean()) ||
+      !almostEqual(a.Variance(0), whole.Variance(0)) ||
+      !almostEqual(a.Skewness(), whole.Skewness()) ||
+      !almostEqual(a.Kurtosis(), whole.Kurtosis()) ||
+      a.Sum() != whole.Sum() || a.Min() != whole.Min() ||
+      a.Max() != whole.Max() {
+      t.Errorf("split at %d: got n=%d mean=%v var=%v skew=%v kurt=%v",
+        split, a.Len(), a.Mean(), a.Variance(0), a.Skewness(), a.Kurtosis())
+    }
+  }
+}
+
+func TestQuantileSketch(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  tests := []struct {
+    name string
+    gen  func(i int) float64
+  }{
+    {"uniform", func(int) float64 { return rng.Float64() }},
+    {"normal", func(int) float64 { return rng.NormFloat64() }},
+    {"ascending", func(i int) float64 { return float64(i) }},
+    {"descending", func(i int) float64 { return float64(-i) }},
+    {"few values", func(int) float64 { return float64(rng.Intn(5)) }},
+  }
+
+  const n, eps = 20000, 0.01
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      sketch := NewQuantileSketch(eps)
+      xs := make([]float64, n)
+      for i := range xs {
+        xs[i] = tt.gen(i)
+        sketch.Add(xs[i])
+      }
+      s := NewSample(xs)
+
+      if len(sketch.tuples) > n/10 {
+        t.Errorf("sketch keeps %d of %d observations", len(sketch.tuples), n)
+      }
+      for _, p := range []float64{0, 0.01, 0.25, 0.5, 0.9, 0.99, 1} {
+        got := sketch.Quantile(p)
+        lo := s.Quantile(math.Max(p-eps, 0))
+        hi := s.Quantile(math.Min(p+eps, 1))
+        if got < lo || got > hi {
+          t.Errorf("Quantile(%v) = %v, want within [%v, %v]", p, got, lo, hi)
+        }
+        blo, bhi := sketch.QuantileBounds(p)
+        if exact := s.Quantile(p); exact < blo || exact > bhi {
+          t.Errorf("QuantileBounds(%v) = [%v, %v], exact %v", p, blo, bhi, exact)
+        }
+      }
+    })
+  }
+}
+
+func TestQuantileBoundsSorted(t *testing.T) {
+  // The default rank error with sorted input, where the estimates are
+  // farthest from the interpolated quantiles.
+  const n, eps = 12454, 0.001
+  tests := []struct {
+    name string
+    gen  func(i int) float64
+  }{
+    {"ascending", func(i int) float64 { return float64(i) }},
+    {"descending", func(i int) float64 { return float64(n - 1 - i) }},
+    {"ascending pairs", func(i int) float64 { return float64(i / 2) }},
+  }
+
+  for _, tt := range tests {
+    sketch := NewQuantileSketch(eps)
+    xs := make([]float64, n)
+    for i := range xs {
+      xs[i] = tt.gen(i)
+      sketch.Add(xs[i])
+    }
+    s := NewSample(xs)
+
+    for p := 0.0; p <= 1; p += 0.005 {
+      lo, hi := sketch.QuantileBounds(p)
+      if exact := s.Quantile(p); exact < lo || exact > hi {
+        t.Errorf("%s: QuantileBounds(%v) = [%v, %v], exact %v", tt.name, p, lo, hi, exact)
+      }
+      if got := sketch.Quantile(p); got < lo || got > hi {
+        t.Errorf("%s: Quantile(%v) = %v, not within [%v, %v]", tt.name, p, got, lo, hi)
+      }
+      if hi-lo > 4*eps*n+2 {
+        t.Errorf("%s: QuantileBounds(%v) = [%v, %v] is too wide", tt.name, p, lo, hi)
+      }
+    }
+  }
+}
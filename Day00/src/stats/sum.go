@@ -0,0 +1,32 @@
+package stats
+
+import "math"
+
+// accumulator adds floating-point numbers with Neumaier's variant of
+// Kahan summation, so the rounding error of the sum does not grow with
+// the number of terms. The zero value is a zero sum.
+type accumulator struct {
+  sum, c float64
+}
+
+func (a *accumulator) Add(x float64) {
+  t := a.sum + x
+  if math.Abs(a.sum) >= math.Abs(x) {
+    a.c += (a.sum - t) + x
+  } else {
+    a.c += (x - t) + a.sum
+  }
+  a.sum = t
+}
+
+func (a *accumulator) Sum() float64 {
+  return a.sum + a.c
+}
+
+func sum(xs []float64) float64 {
+  var acc accumulator
+  for _, x := range xs {
+    acc.Add(x)
+  }
+  return acc.Sum()
+}
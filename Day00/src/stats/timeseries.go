@@ -0,0 +1,157 @@
+package stats
+
+import (
+  "math"
+  "slices"
+)
+
+// Autocorrelation returns the sample autocorrelation of the series xs
+// at the lag: the covariance of the series with itself shifted by lag
+// observations, divided by its variance.
+func Autocorrelation[T Number](xs []T, lag int) float64 {
+  fs := toFloats(xs)
+  if lag < 0 || lag >= len(fs) {
+    return math.NaN()
+  }
+
+  avg := mean(fs)
+  var num, den accumulator
+  for i, x := range fs {
+    den.Add((x - avg) * (x - avg))
+    if i+lag < len(fs) {
+      num.Add((x - avg) * (fs[i+lag] - avg))
+    }
+  }
+  return num.Sum() / den.Sum()
+}
+
+// MovingAverage returns the averages of every n consecutive observations
+// of the series xs; the i-th average ends at the observation i+n-1.
+func MovingAverage[T Number](xs []T, n int) []float64 {
+  fs := toFloats(xs)
+  if n < 1 || n > len(fs) {
+    return nil
+  }
+
+  avgs := make([]float64, 0, len(fs)-n+1)
+  for i := n; i <= len(fs); i++ {
+    avgs = append(avgs, mean(fs[i-n:i]))
+  }
+  return avgs
+}
+
+// Trend returns the least squares line of the series xs over the
+// indices of its observations, 0, 1, ...
+func Trend[T Number](xs []T) Regression {
+  return LinearRegression(indices(len(xs)), toFloats(xs))
+}
+
+// TrendTest tests whether the slope of the trend of xs differs from
+// zero. The statistic is Student's t of the slope with n-2 degrees of
+// freedom.
+func TrendTest[T Number](xs []T) TestResult {
+  fs := toFloats(xs)
+  n := len(fs)
+  if n < 3 {
+    return TestResult{Statistic: math.NaN(), P: math.NaN()}
+  }
+
+  ts := indices(n)
+  line := LinearRegression(ts, fs)
+  // The sum of squared deviations of 0..n-1 from their mean.
+  sxx := float64(n) * float64(n*n-1) / 12
+  t := line.Slope / (line.StdErr(ts, fs) / math.Sqrt(sxx))
+  df := float64(n - 2)
+  return TestResult{t, df, 2 * StudentTCDF(-math.Abs(t), df)}
+}
+
+func indices(n int) []float64 {
+  ts := make([]float64, n)
+  for i := range ts {
+    ts[i] = float64(i)
+  }
+  return ts
+}
+
+// ChangePoint is a shift of the mean of a series.
+type ChangePoint struct {
+  // Index is the index of the first observation after the shift.
+  Index int
+  // Before and After are the means of the segments of the series
+  // around the change point.
+  Before, After float64
+  // P is the p-value of the shift in the segment it was found in.
+  P float64
+}
+
+// ChangePoints finds the shifts of the mean of the series xs by binary
+// segmentation with the CUSUM statistic: a segment is split where the
+// cumulative sum of deviations from its mean is the largest in absolute
+// value, if this is significant at the level alpha and both parts have
+// at least minSize observations. The p-value is by the Kolmogorov
+// distribution of the supremum of the Brownian bridge, with the standard
+// deviation estimated from the differences of consecutive observations,
+// which does not grow with the shifts.
+func ChangePoints[T Number](xs []T, alpha float64, minSize int) []ChangePoint {
+  fs := toFloats(xs)
+  minSize = max(minSize, 1)
+  if len(fs) < 2*minSize || len(fs) < 3 {
+    return nil
+  }
+
+  diffs := make([]float64, len(fs)-1)
+  for i := range diffs {
+    diffs[i] = math.Abs(fs[i+1] - fs[i])
+  }
+  // The MAD of a normal variable is 0.6745 of its standard deviation
+  // and the differences have twice the variance of the observations.
+  sigma := NewSample(diffs).Median() / (0.6745 * math.Sqrt2)
+  if sigma == 0 {
+    sigma = math.Sqrt(withDDOF(variance(fs), len(fs), 1))
+  }
+  if !(sigma > 0) {
+    return nil
+  }
+
+  var points []ChangePoint
+  var segment func(lo, hi int)
+  segment = func(lo, hi int) {
+    if hi-lo < 2*minSize {
+      return
+    }
+    avg := mean(fs[lo:hi])
+    var cusum, peak float64
+    at := -1
+    for k := lo; k < hi-1; k++ {
+      cusum += fs[k] - avg
+      if k+1-lo >= minSize && hi-k-1 >= minSize && math.Abs(cusum) > peak {
+        peak, at = math.Abs(cusum), k+1
+      }
+    }
+    if at < 0 {
+      return
+    }
+    p := KolmogorovSurvival(peak / (sigma * math.Sqrt(float64(hi-lo))))
+    if p >= alpha {
+      return
+    }
+    points = append(points, ChangePoint{Index: at, P: p})
+    segment(lo, at)
+    segment(at, hi)
+  }
+  segment(0, len(fs))
+
+  slices.SortFunc(points, func(a, b ChangePoint) int { return a.Index - b.Index })
+  for i := range points {
+    lo, hi := 0, len(fs)
+    if i > 0 {
+      lo = points[i-1].Index
+    }
+    if i < len(points)-1 {
+      hi = points[i+1].Index
+    }
+    points[i].Before = mean(fs[lo:points[i].Index])
+    points[i].After = mean(fs[points[i].Index:hi])
+  }
+  return points
+}
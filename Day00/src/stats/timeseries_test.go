@@ -0,0 +1,72 @@
+package stats
+
+import (
+  "math/rand"
+  "slices"
+  "testing"
+)
+
+func TestAutocorrelation(t *testing.T) {
+  xs := []int{1, 2, 3, 4, 5}
+  for _, tt := range []struct {
+    lag  int
+    want float64
+  }{
+    {0, 1}, {1, 0.4}, {2, -0.1}, {4, -0.4},
+  } {
+    if got := Autocorrelation(xs, tt.lag); !almostEqual(got, tt.want) {
+      t.Errorf("Autocorrelation(%d) = %v, want %v", tt.lag, got, tt.want)
+    }
+  }
+
+  if got := MovingAverage(xs, 3); !slices.Equal(got, []float64{2, 3, 4}) {
+    t.Errorf("MovingAverage(3) = %v", got)
+  }
+  if got := MovingAverage(xs, 6); got != nil {
+    t.Errorf("MovingAverage(6) = %v, want nil", got)
+  }
+}
+
+func TestTrend(t *testing.T) {
+  line := Trend([]float64{2, 5, 8, 11})
+  if !almostEqual(line.Slope, 3) || !almostEqual(line.Intercept, 2) {
+    t.Errorf("Trend = %+v, want slope 3, intercept 2", line)
+  }
+
+  rng := rand.New(rand.NewSource(1))
+  rising, flat := make([]float64, 100), make([]float64, 100)
+  for i := range rising {
+    rising[i] = 0.1*float64(i) + rng.NormFloat64()
+    flat[i] = rng.NormFloat64()
+  }
+  if p := TrendTest(rising).P; !(p < 1e-6) {
+    t.Errorf("TrendTest(rising).P = %v, want < 1e-6", p)
+  }
+  if p := TrendTest(flat).P; !(p > 0.05) {
+    t.Errorf("TrendTest(flat).P = %v, want > 0.05", p)
+  }
+}
+
+func TestChangePoints(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  xs := make([]float64, 150)
+  for i := range xs {
+    xs[i] = rng.NormFloat64()
+    if i >= 60 && i < 100 {
+      xs[i] += 5
+    }
+  }
+
+  points := ChangePoints(xs, 0.01, 5)
+  if len(points) != 2 || points[0].Index != 60 || points[1].Index != 100 {
+    t.Fatalf("ChangePoints = %+v, want shifts at 60 and 100", points)
+  }
+  if !almostEqual(points[0].After, points[1].Before) ||
+    points[0].After-points[0].Before < 4 || points[0].P > 0.01 {
+    t.Errorf("ChangePoints = %+v", points)
+  }
+
+  if points := ChangePoints(xs[:60], 0.01, 5); len(points) != 0 {
+    t.Errorf("ChangePoints of a stationary series = %+v", points)
+  }
+}
@@ -0,0 +1,271 @@
+package stats
+
+import (
+  "math"
+  "sort"
+)
+
+// Weights is the meaning of the weights of a WeightedSample. It only
+// matters for the variance and the statistics derived from it.
+type Weights int
+
+const (
+  // FrequencyWeights are counts of occurrences: a value of weight 3
+  // stands for three equal observations.
+  FrequencyWeights Weights = iota
+  // ReliabilityWeights are relative importances of the observations,
+  // e.g. inverse variances, and their scale does not matter.
+  ReliabilityWeights
+)
+
+// WeightedSample is a sorted set of distinct observations with
+// non-negative weights. With frequency weights the quantiles equal
+// those of the sample in which every value is repeated as many times
+// as its weight. With reliability weights they depend only on the
+// relative weights.
+type WeightedSample struct {
+  xs, ws []float64
+  cum    []float64 // cumulative weights
+  v2     float64   // sum of squared weights of the observations
+  kind   Weights
+}
+
+// NewWeightedSample returns the sample of values with the weights of
+// the given kind. Equal values are merged and values of zero weight
+// are left out. It panics if the slices differ in length or a weight
+// is negative or not finite.
+func NewWeightedSample[T, U Number](values []T, weights []U, kind Weights) *WeightedSample {
+  if len(values) != len(weights) {
+    panic("stats: values and weights differ in length")
+  }
+
+  idx := make([]int, 0, len(values))
+  s := &WeightedSample{kind: kind}
+  for i, w := range weights {
+    w := float64(w)
+    if !(w >= 0) || math.IsInf(w, 0) {
+      panic("stats: invalid weight")
+    }
+    if w > 0 {
+      idx = append(idx, i)
+      s.v2 += w * w
+    }
+  }
+  sort.SliceStable(idx, func(i, j int) bool {
+    return values[idx[i]] < values[idx[j]]
+  })
+
+  var total accumulator
+  for _, i := range idx {
+    x, w := float64(values[i]), float64(weights[i])
+    total.Add(w)
+    if n := len(s.xs); n != 0 && s.xs[n-1] == x {
+      s.ws[n-1] += w
+      s.cum[n-1] = total.Sum()
+      continue
+    }
+    s.xs = append(s.xs, x)
+    s.ws = append(s.ws, w)
+    s.cum = append(s.cum, total.Sum())
+  }
+  return s
+}
+
+// Len returns the number of distinct observations.
+func (s *WeightedSample) Len() int {
+  return len(s.xs)
+}
+
+// Weight returns the sum of the weights.
+func (s *WeightedSample) Weight() float64 {
+  if len(s.cum) == 0 {
+    return 0
+  }
+  return s.cum[len(s.cum)-1]
+}
+
+// Size returns the sample size the variance is corrected for: the sum
+// of the weights for frequency weights, and Kish's effective sample
+// size, the squared sum of the weights divided by the sum of their
+// squares, for reliability weights.
+func (s *WeightedSample) Size() float64 {
+  w := s.Weight()
+  if s.kind == ReliabilityWeights && w != 0 {
+    return w * w / s.v2
+  }
+  return w
+}
+
+// Sum returns the weighted sum of the observations.
+func (s *WeightedSample) Sum() float64 {
+  var acc accumulator
+  for i, x := range s.xs {
+    acc.Add(s.ws[i] * x)
+  }
+  return acc.Sum()
+}
+
+// Mean returns the weighted arithmetic mean.
+func (s *WeightedSample) Mean() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.Sum() / s.Weight()
+}
+
+// Median returns the weighted median, see Quantile.
+func (s *WeightedSample) Median() float64 {
+  return s.Quantile(0.5)
+}
+
+// Mode returns the observation of the largest weight. If there are
+// several, the smallest one is returned.
+func (s *WeightedSample) Mode() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  mode := 0
+  for i, w := range s.ws {
+    if w > s.ws[mode] {
+      mode = i
+    }
+  }
+  return s.xs[mode]
+}
+
+// Quantile returns the weighted p-quantile, 0 <= p <= 1. For frequency
+// weights it is linearly interpolated like Sample.Quantile between the
+// observations at the ranks p(W-1) of the expanded sample, where W is
+// the sum of weights. For reliability weights every observation is
+// placed at the middle of its share of the cumulative weight,
+// (C - w/2)/W, and the quantile is interpolated between these
+// positions, so it does not change when the weights are scaled.
+func (s *WeightedSample) Quantile(p float64) float64 {
+  if len(s.xs) == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  if s.kind == ReliabilityWeights {
+    return s.midQuantile(p)
+  }
+
+  h := max(p*(s.Weight()-1), 0)
+  lo := math.Floor(h)
+  a, b := s.at(lo), s.at(lo+1)
+  return a + (h-lo)*(b-a)
+}
+
+// midQuantile returns the p-quantile interpolated between the middles
+// of the shares of the observations in the cumulative weight.
+func (s *WeightedSample) midQuantile(p float64) float64 {
+  w := s.Weight()
+  mid := func(i int) float64 { return (s.cum[i] - s.ws[i]/2) / w }
+  i := sort.Search(len(s.xs), func(i int) bool { return mid(i) >= p })
+  switch i {
+  case 0:
+    return s.xs[0]
+  case len(s.xs):
+    return s.xs[len(s.xs)-1]
+  }
+  lo, hi := mid(i-1), mid(i)
+  return s.xs[i-1] + (p-lo)/(hi-lo)*(s.xs[i]-s.xs[i-1])
+}
+
+// at returns the observation at the 0-based rank t of the expanded
+// sample, or the largest observation if t exceeds the ranks.
+func (s *WeightedSample) at(t float64) float64 {
+  i := sort.Search(len(s.cum), func(i int) bool { return s.cum[i] > t })
+  return s.xs[min(i, len(s.xs)-1)]
+}
+
+// StdDev returns the weighted population standard deviation.
+func (s *WeightedSample) StdDev() float64 {
+  return math.Sqrt(s.Variance(0))
+}
+
+// Variance returns the weighted mean of squared deviations from the
+// mean, corrected for ddof delta degrees of freedom: it is multiplied
+// by n/(n-ddof), where n is the Size of the sample. With ddof 1 the
+// variance is unbiased for either kind of weights.
+func (s *WeightedSample) Variance(ddof int) float64 {
+  n := s.Size()
+  if n <= float64(ddof) {
+    return math.NaN()
+  }
+  return s.centralMoment(2) * n / (n - float64(ddof))
+}
+
+// StdErr returns the standard error of the weighted mean, the standard
+// deviation with the given ddof divided by the square root of Size.
+func (s *WeightedSample) StdErr(ddof int) float64 {
+  return math.Sqrt(s.Variance(ddof) / s.Size())
+}
+
+// Min returns the smallest observation.
+func (s *WeightedSample) Min() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.xs[0]
+}
+
+// Max returns the largest observation.
+func (s *WeightedSample) Max() float64 {
+  if len(s.xs) == 0 {
+    return math.NaN()
+  }
+  return s.xs[len(s.xs)-1]
+}
+
+// Range returns the difference between the largest and the smallest
+// observations.
+func (s *WeightedSample) Range() float64 {
+  return s.Max() - s.Min()
+}
+
+// IQR returns the weighted interquartile range.
+func (s *WeightedSample) IQR() float64 {
+  return s.Quantile(0.75) - s.Quantile(0.25)
+}
+
+// MAD returns the weighted median absolute deviation from the weighted
+// median.
+func (s *WeightedSample) MAD() float64 {
+  med := s.Median()
+  dev := make([]float64, len(s.xs))
+  for i, x := range s.xs {
+    dev[i] = math.Abs(x - med)
+  }
+  return NewWeightedSample(dev, s.ws, s.kind).Median()
+}
+
+// CV returns the coefficient of variation, the ratio of the weighted
+// population standard deviation to the weighted mean.
+func (s *WeightedSample) CV() float64 {
+  return s.StdDev() / s.Mean()
+}
+
+// Skewness returns the weighted population skewness.
+func (s *WeightedSample) Skewness() float64 {
+  m2, m3 := s.centralMoment(2), s.centralMoment(3)
+  return m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis returns the weighted population excess kurtosis.
+func (s *WeightedSample) Kurtosis() float64 {
+  m2, m4 := s.centralMoment(2), s.centralMoment(4)
+  return m4/(m2*m2) - 3
+}
+
+func (s *WeightedSample) centralMoment(k int) float64 {
+  avg := s.Mean()
+
+  var acc accumulator
+  for i, x := range s.xs {
+    d, p := x-avg, x-avg
+    for j := 1; j < k; j++ {
+      p *= d
+    }
+    acc.Add(s.ws[i] * p)
+  }
+  return acc.Sum() / s.Weight()
+}
@@ -0,0 +1,149 @@
+package stats
+
+import (
+  "errors"
+  "math"
+  "slices"
+  "strings"
+  "testing"
+)
+
+func TestWeightedExpanded(t *testing.T) {
+  values := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+  weights := []int{2, 1, 0, 3, 1, 4, 2, 1}
+
+  var expanded []float64
+  for i, x := range values {
+    for j := 0; j < weights[i]; j++ {
+      expanded = append(expanded, x)
+    }
+  }
+  s, w := NewSample(expanded), NewWeightedSample(values, weights, FrequencyWeights)
+
+  if w.Len() != 6 || w.Weight() != 14 || w.Size() != 14 {
+    t.Errorf("Len, Weight, Size = %d, %v, %v, want 6, 14, 14",
+      w.Len(), w.Weight(), w.Size())
+  }
+  for _, tt := range []struct {
+    metric    string
+    got, want float64
+  }{
+    {"Sum", w.Sum(), s.Sum()},
+    {"Mean", w.Mean(), s.Mean()},
+    {"Median", w.Median(), s.Median()},
+    {"Mode", w.Mode(), s.Mode()},
+    {"Quantile(0.1)", w.Quantile(0.1), s.Quantile(0.1)},
+    {"Quantile(0.33)", w.Quantile(0.33), s.Quantile(0.33)},
+    {"Quantile(1)", w.Quantile(1), s.Quantile(1)},
+    {"IQR", w.IQR(), s.IQR()},
+    {"MAD", w.MAD(), s.MAD()},
+    {"StdDev", w.StdDev(), s.StdDev()},
+    {"Variance(1)", w.Variance(1), s.Variance(1)},
+    {"StdErr(1)", w.StdErr(1), s.StdErr(1)},
+    {"Skewness", w.Skewness(), s.Skewness()},
+    {"Kurtosis", w.Kurtosis(), s.Kurtosis()},
+    {"Range", w.Range(), s.Range()},
+  } {
+    if !almostEqual(tt.got, tt.want) {
+      t.Errorf("%s = %v, want %v", tt.metric, tt.got, tt.want)
+    }
+  }
+}
+
+func TestReliabilityWeights(t *testing.T) {
+  values := []float64{1, 2, 4}
+  weights := []float64{0.5, 0.25, 0.25}
+  w := NewWeightedSample(values, weights, ReliabilityWeights)
+
+  // The unbiased estimate is Σw(x-m)² / (V1 - V2/V1).
+  mean := 0.5*1 + 0.25*2 + 0.25*4
+  ss := 0.5*(1-mean)*(1-mean) + 0.25*(2-mean)*(2-mean) +
+    0.25*(4-mean)*(4-mean)
+  v2 := 0.5*0.5 + 2*0.25*0.25
+  if got, want := w.Variance(1), ss/(1-v2); !almostEqual(got, want) {
+    t.Errorf("Variance(1) = %v, want %v", got, want)
+  }
+  if got := w.Size(); !almostEqual(got, 1/v2) {
+    t.Errorf("Size() = %v, want %v", got, 1/v2)
+  }
+
+  // The observations are placed at 0.25, 0.625 and 0.875 of the
+  // cumulative weight.
+  for _, tt := range []struct {
+    metric    string
+    got, want float64
+  }{
+    {"Quantile(0.1)", w.Quantile(0.1), 1},
+    {"Median", w.Median(), 1 + 0.25/0.375},
+    {"Quantile(0.75)", w.Quantile(0.75), 3},
+    {"Quantile(1)", w.Quantile(1), 4},
+  } {
+    if !almostEqual(tt.got, tt.want) {
+      t.Errorf("%s = %v, want %v", tt.metric, tt.got, tt.want)
+    }
+  }
+
+  // Scaling reliability weights changes nothing.
+  for _, factor := range []float64{80, 0.01} {
+    ws := make([]float64, len(weights))
+    for i := range weights {
+      ws[i] = weights[i] * factor
+    }
+    scaled := NewWeightedSample(values, ws, ReliabilityWeights)
+    for _, tt := range []struct {
+      metric    string
+      got, want float64
+    }{
+      {"Variance(1)", scaled.Variance(1), w.Variance(1)},
+      {"Quantile(0.3)", scaled.Quantile(0.3), w.Quantile(0.3)},
+      {"Median", scaled.Median(), w.Median()},
+      {"IQR", scaled.IQR(), w.IQR()},
+      {"MAD", scaled.MAD(), w.MAD()},
+    } {
+      if !almostEqual(tt.got, tt.want) {
+        t.Errorf("scaled by %v: %s = %v, want %v", factor, tt.metric, tt.got, tt.want)
+      }
+    }
+  }
+
+  // With two observations the median lies between them.
+  for _, ws := range [][]float64{{0.3, 0.7}, {3, 7}} {
+    s := NewWeightedSample([]float64{1, 2}, ws, ReliabilityWeights)
+    if got := s.Median(); !almostEqual(got, 1.7) {
+      t.Errorf("weights %v: Median() = %v, want 1.7", ws, got)
+    }
+  }
+}
+
+func TestWeightedEmpty(t *testing.T) {
+  w := NewWeightedSample([]float64{1, 2}, []float64{0, 0}, FrequencyWeights)
+  for _, got := range []float64{w.Mean(), w.Median(), w.Mode(),
+    w.Variance(0), w.Min()} {
+    if !math.IsNaN(got) {
+      t.Errorf("got %v, want NaN", got)
+    }
+  }
+}
+
+func TestReadWeighted(t *testing.T) {
+  rd := Reader{Range: Range{-10, 10}}
+  xs, ws, err := rd.ReadWeighted(strings.NewReader("1 2\n3,1e6\n"))
+  if err != nil || !slices.Equal(xs, []float64{1, 3}) ||
+    !slices.Equal(ws, []float64{2, 1e6}) {
+    t.Errorf("got %v %v %v", xs, ws, err)
+  }
+
+  for _, tt := range []struct {
+    in   string
+    want error
+  }{
+    {"1\n", ErrFieldCount},
+    {"11 1\n", ErrRange},
+    {"1 -1\n", ErrRange},
+    {"1 x\n", ErrNotNumber},
+  } {
+    if _, _, err := rd.ReadWeighted(strings.NewReader(tt.in)); !errors.Is(err, tt.want) {
+      t.Errorf("%q: err = %v, want %v", tt.in, err, tt.want)
+    }
+  }
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+  "math"
+  "sort"
+)
+
+// Window is a queue of the latest observations of a stream that also
+// keeps them sorted, so the statistics of a sliding window are updated
+// in linear time.
+type Window struct {
+  queue  []float64
+  sorted []float64
+}
+
+// Push appends an observation to the window.
+func (w *Window) Push(x float64) {
+  w.queue = append(w.queue, x)
+
+  i := sort.SearchFloat64s(w.sorted, x)
+  w.sorted = append(w.sorted, 0)
+  copy(w.sorted[i+1:], w.sorted[i:])
+  w.sorted[i] = x
+}
+
+// Pop removes the oldest observation from the window and returns it.
+// It panics if the window is empty.
+func (w *Window) Pop() float64 {
+  x := w.queue[0]
+  w.queue = w.queue[1:]
+
+  i := sort.SearchFloat64s(w.sorted, x)
+  w.sorted = append(w.sorted[:i], w.sorted[i+1:]...)
+  return x
+}
+
+// Len returns the number of observations in the window.
+func (w *Window) Len() int {
+  return len(w.queue)
+}
+
+// Sample returns the observations in the window as a sample. The sample
+// is only valid until the next change of the window.
+func (w *Window) Sample() *Sample {
+  return &Sample{w.sorted}
+}
+
+// EWMA is an exponentially weighted moving average: every observation
+// x updates the average to Alpha*x + (1-Alpha)*average. The first
+// observation is the initial average.
+type EWMA struct {
+  Alpha float64
+  value float64
+  n     int
+}
+
+// Add adds an observation.
+func (e *EWMA) Add(x float64) {
+  if e.n == 0 {
+    e.value = x
+  } else {
+    e.value += e.Alpha * (x - e.value)
+  }
+  e.n++
+}
+
+// Value returns the current average.
+func (e *EWMA) Value() float64 {
+  if e.n == 0 {
+    return math.NaN()
+  }
+  return e.value
+}
@@ -0,0 +1,41 @@
+package stats
+
+import (
+  "math/rand"
+  "slices"
+  "testing"
+)
+
+func TestWindow(t *testing.T) {
+  const size = 50
+  rng := rand.New(rand.NewSource(1))
+
+  var w Window
+  var xs []float64
+  for i := 0; i < 1000; i++ {
+    x := float64(rng.Intn(20))
+    w.Push(x)
+    xs = append(xs, x)
+    if w.Len() > size {
+      if got := w.Pop(); got != xs[0] {
+        t.Fatalf("Pop() = %v, want %v", got, xs[0])
+      }
+      xs = xs[1:]
+    }
+
+    want := NewSample(xs)
+    if !slices.Equal(w.Sample().Values(), want.Values()) {
+      t.Fatalf("step %d: window %v, want %v", i, w.Sample().Values(), want.Values())
+    }
+  }
+}
+
+func TestEWMA(t *testing.T) {
+  e := EWMA{Alpha: 0.5}
+  for _, x := range []float64{10, 20, 0} {
+    e.Add(x)
+  }
+  if got := e.Value(); got != 7.5 {
+    t.Errorf("Value() = %v, want 7.5", got)
+  }
+}
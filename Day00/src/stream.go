@@ -0,0 +1,86 @@
+package main
+
+import (
+  "anscombe/stats"
+  "fmt"
+  "math"
+  "os"
+)
+
+// streamed are the metrics computed exactly in stream mode.
+var streamed = map[string]func(m *stats.Moments) float64{
+  "count": func(m *stats.Moments) float64 { return float64(m.Len()) },
+  "sum":   (*stats.Moments).Sum,
+  "mean":  (*stats.Moments).Mean,
+  "deviation": func(m *stats.Moments) float64 {
+    return math.Sqrt(m.Variance(ddofFlag))
+  },
+  "variance": func(m *stats.Moments) float64 { return m.Variance(ddofFlag) },
+  "sem": func(m *stats.Moments) float64 {
+    return math.Sqrt(m.Variance(ddofFlag) / float64(m.Len()))
+  },
+  "minimum": (*stats.Moments).Min,
+  "maximum": (*stats.Moments).Max,
+  "range":   func(m *stats.Moments) float64 { return m.Max() - m.Min() },
+  "cv": func(m *stats.Moments) float64 {
+    return math.Sqrt(m.Variance(ddofFlag)) / m.Mean()
+  },
+  "skewness": (*stats.Moments).Skewness,
+  "kurtosis": (*stats.Moments).Kurtosis,
+}
+
+// stream computes the statistics without keeping the input in memory.
+// Quantiles are estimates printed with the interval containing the
+// exact value. Metrics that need the whole sample are not printed.
+func stream(reader *stats.Reader, eps float64) int {
+  var moments stats.Moments
+  sketch := stats.NewQuantileSketch(eps)
+
+  err := reader.ScanFloats(os.Stdin, func(num float64) {
+    moments.Add(num)
+    sketch.Add(num)
+  })
+  if err == nil && moments.Len() == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintln(os.Stderr, errmsg(err, reader.Range))
+    return exitInvalidInput
+  }
+
+  r := streamReport(&moments, sketch)
+  return r.print()
+}
+
+// streamReport returns the report of the selected metrics of numbers
+// accumulated in the moments and the sketch.
+func streamReport(moments *stats.Moments, sketch *stats.QuantileSketch) report {
+  sketched := quantiles()
+
+  var r report
+  for _, m := range selected() {
+    if f, ok := streamed[m.name]; ok {
+      r.fields = append(r.fields, m.field(f(moments)))
+      continue
+    }
+
+    var x, lo, hi float64
+    if p, ok := sketched[m.name]; ok {
+      x = sketch.Quantile(p)
+      lo, hi = sketch.QuantileBounds(p)
+    } else if m.name == "iqr" {
+      q1lo, q1hi := sketch.QuantileBounds(0.25)
+      q3lo, q3hi := sketch.QuantileBounds(0.75)
+      x = sketch.Quantile(0.75) - sketch.Quantile(0.25)
+      lo, hi = max(q3lo-q1hi, 0), q3hi-q1lo
+    } else {
+      continue
+    }
+    f := m.field(x)
+    f.estimate, f.lo, f.hi = true, lo, hi
+    r.fields = append(r.fields, f)
+  }
+  r.fields = append(r.fields, field{key: "rank_error", title: "Rank error",
+    value: sketch.Epsilon(), exact: true, setting: true})
+  return r
+}
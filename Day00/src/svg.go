@@ -0,0 +1,242 @@
+package main
+
+import (
+  "anscombe/stats"
+  "flag"
+  "fmt"
+  "html"
+  "math"
+  "os"
+  "strconv"
+  "strings"
+)
+
+// Size of a panel of an SVG chart and its margins, in pixels.
+const (
+  panelWidth   = 640
+  panelHeight  = 320
+  marginLeft   = 60
+  marginRight  = 20
+  marginTop    = 30
+  marginBottom = 40
+)
+
+var plotFlag string
+
+func init() {
+  flag.StringVar(&plotFlag, "plot", "",
+    "A string. Write an SVG chart to the file: the histogram, the box\n"+
+      "plot if -box is set without -hist, or the scatter plot with the\n"+
+      "regression line of -pairs")
+}
+
+// svg is an SVG image made of panels stacked vertically.
+type svg struct {
+  sb     strings.Builder
+  panels int
+}
+
+// axis maps the interval [lo, hi] of data to [from, to] in pixels.
+type axis struct {
+  lo, hi, from, to float64
+}
+
+func (a axis) at(x float64) float64 {
+  if a.hi == a.lo {
+    return (a.from + a.to) / 2
+  }
+  return a.from + fraction(x, a.lo, a.hi)*(a.to-a.from)
+}
+
+// panel starts a new panel with the title and returns its x and y axes
+// for the data ranges, drawing the ticks of x and, if ticksY is set,
+// of y.
+func (s *svg) panel(title string, xlo, xhi, ylo, yhi float64, ticksY bool) (x, y axis) {
+  top := float64(s.panels * panelHeight)
+  s.panels++
+  x = axis{xlo, xhi, marginLeft, panelWidth - marginRight}
+  y = axis{ylo, yhi, top + panelHeight - marginBottom, top + marginTop}
+
+  fmt.Fprintf(&s.sb, "<text x=\"%d\" y=\"%.1f\" font-weight=\"bold\">%s</text>\n",
+    marginLeft, top+marginTop-10, html.EscapeString(title))
+  fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fH%.1f\" stroke=\"black\"/>\n",
+    x.from, y.from, x.to)
+  for _, t := range ticks(xlo, xhi) {
+    fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fv5\" stroke=\"black\"/>"+
+      "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\">%s</text>\n",
+      x.at(t.value), y.from, x.at(t.value), y.from+20, t.label)
+  }
+  if ticksY {
+    fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fV%.1f\" stroke=\"black\"/>\n",
+      x.from, y.from, y.to)
+    for _, t := range ticks(ylo, yhi) {
+      fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fh-5\" stroke=\"black\"/>"+
+        "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\">%s</text>\n",
+        x.from, y.at(t.value), x.from-8, y.at(t.value)+4, t.label)
+    }
+  }
+  return x, y
+}
+
+// write writes the image to the file.
+func (s *svg) write(name string) error {
+  image := fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\""+
+    " width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"12\">\n"+
+    "<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n%s</svg>\n",
+    panelWidth, s.panels*panelHeight, s.sb.String())
+  return os.WriteFile(name, []byte(image), 0o644)
+}
+
+// tick is a labeled value on an axis.
+type tick struct {
+  value float64
+  label string
+}
+
+// ticks returns about five round values between lo and hi.
+func ticks(lo, hi float64) []tick {
+  if !(hi > lo) {
+    return []tick{{lo, strconv.FormatFloat(lo, 'g', -1, 64)}}
+  }
+  if math.IsInf(hi-lo, 0) {
+    return []tick{
+      {lo, strconv.FormatFloat(lo, 'g', 3, 64)},
+      {hi, strconv.FormatFloat(hi, 'g', 3, 64)},
+    }
+  }
+
+  base := math.Pow(10, math.Floor(math.Log10((hi-lo)/5)))
+  var step float64
+  for _, m := range []float64{1, 2, 5, 10} {
+    if step = base * m; (hi-lo)/step <= 7 {
+      break
+    }
+  }
+  decimals := max(0, int(-math.Floor(math.Log10(step))))
+
+  // The ticks are counted rather than accumulated, as the step may be
+  // smaller than the precision of the values.
+  var ts []tick
+  start := math.Ceil(lo / step)
+  for i := 0; i <= 10; i++ {
+    v := (start + float64(i)) * step
+    if v > hi+step/1e6 {
+      break
+    }
+    if len(ts) != 0 && v == ts[len(ts)-1].value {
+      continue
+    }
+    ts = append(ts, tick{v, strconv.FormatFloat(v, 'f', decimals, 64)})
+  }
+  return ts
+}
+
+// histogram draws the bins as bars.
+func (s *svg) histogram(bins []stats.Bin) {
+  lo, hi := bins[0].Lo, bins[len(bins)-1].Hi
+  if lo == hi {
+    lo, hi = lo-0.5, hi+0.5
+  }
+  peak := 0
+  for _, b := range bins {
+    peak = max(peak, b.Count)
+  }
+
+  x, y := s.panel("Histogram", lo, hi, 0, float64(peak), true)
+  for _, b := range bins {
+    left, right := x.at(b.Lo), x.at(b.Hi)
+    if b.Lo == b.Hi {
+      left, right = x.from, x.to
+    }
+    fmt.Fprintf(&s.sb, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\""+
+      " height=\"%.1f\" fill=\"steelblue\" stroke=\"white\">"+
+      "<title>[%s, %s]: %d</title></rect>\n",
+      left, y.at(float64(b.Count)), right-left,
+      y.from-y.at(float64(b.Count)),
+      formatNumber(b.Lo, false), formatNumber(b.Hi, false), b.Count)
+  }
+}
+
+// boxPlot draws the box plot of the sample.
+func (s *svg) boxPlot(sample *stats.Sample) {
+  b := sample.BoxPlot()
+  title := fmt.Sprintf("Box plot: whiskers %s..%s, box %s..%s, median %s",
+    formatNumber(b.Lower, false), formatNumber(b.Upper, false),
+    formatNumber(b.Q1, false), formatNumber(b.Q3, false),
+    formatNumber(b.Median, false))
+  x, y := s.panel(title, sample.Min(), sample.Max(), 0, 1, false)
+
+  mid, half := y.at(0.5), (y.from-y.to)/4
+  fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fH%.1fM%.1f %.1fH%.1f"+
+    "M%.1f %.1fv%.1fM%.1f %.1fv%.1f\" stroke=\"black\"/>\n",
+    x.at(b.Lower), mid, x.at(b.Q1), x.at(b.Q3), mid, x.at(b.Upper),
+    x.at(b.Lower), mid-half/2, half, x.at(b.Upper), mid-half/2, half)
+  fmt.Fprintf(&s.sb, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\""+
+    " height=\"%.1f\" fill=\"lightsteelblue\" stroke=\"black\"/>\n",
+    x.at(b.Q1), mid-half, x.at(b.Q3)-x.at(b.Q1), 2*half)
+  fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fv%.1f\" stroke=\"black\""+
+    " stroke-width=\"3\"/>\n", x.at(b.Median), mid-half, 2*half)
+  for _, o := range b.Outliers {
+    fmt.Fprintf(&s.sb, "<circle cx=\"%.1f\" cy=\"%.1f\" r=\"3\""+
+      " fill=\"none\" stroke=\"black\"><title>%s</title></circle>\n",
+      x.at(o), mid, formatNumber(o, true))
+  }
+}
+
+// scatter draws the points and the regression line. The line is left
+// out if it is not defined, when all x are equal, and R² if all y are.
+func (s *svg) scatter(xs, ys []float64, line stats.Regression) {
+  xlo, xhi := stats.Min(xs), stats.Max(xs)
+  ylo, yhi := stats.Min(ys), stats.Max(ys)
+  defined := !math.IsNaN(line.Slope) && !math.IsInf(line.Slope, 0)
+  title := "Scatter plot"
+  if defined {
+    title = fmt.Sprintf("y = %s + %s x",
+      formatNumber(line.Intercept, false), formatNumber(line.Slope, false))
+  }
+  if defined && !math.IsNaN(line.RSquared) {
+    title += ", R² = " + formatNumber(line.RSquared, false)
+  }
+  x, y := s.panel(title, xlo, xhi, ylo, yhi, true)
+
+  for i := range xs {
+    fmt.Fprintf(&s.sb, "<circle cx=\"%.1f\" cy=\"%.1f\" r=\"3\""+
+      " fill=\"steelblue\"><title>%s, %s</title></circle>\n",
+      x.at(xs[i]), y.at(ys[i]),
+      formatNumber(xs[i], true), formatNumber(ys[i], true))
+  }
+  if !defined {
+    return
+  }
+  fmt.Fprintf(&s.sb, "<path d=\"M%.1f %.1fL%.1f %.1f\" stroke=\"firebrick\""+
+    " stroke-width=\"2\"/>\n", x.at(xlo), y.at(line.Predict(xlo)),
+    x.at(xhi), y.at(line.Predict(xhi)))
+}
+
+// plotSample writes the histogram of the sample, or its box plot if
+// only -box is set, to the file of -plot and returns the exit status.
+func plotSample(sample *stats.Sample) int {
+  var s svg
+  if histFlag || !boxFlag {
+    bins, err := histogram(sample)
+    if err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return exitUsage
+    }
+    s.histogram(bins)
+  }
+  if boxFlag {
+    s.boxPlot(sample)
+  }
+  return writePlot(&s)
+}
+
+// writePlot writes the image to the file of -plot and returns the exit
+// status.
+func writePlot(s *svg) int {
+  if err := s.write(plotFlag); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    return exitFailure
+  }
+  return exitOK
+}
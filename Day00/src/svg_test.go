@@ -0,0 +1,123 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/xml"
+  "errors"
+  "io"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+)
+
+// checkSVG parses the image in the file and fails if it is not well
+// formed or any of its attributes or texts is not finite.
+func checkSVG(t *testing.T, name, file string) {
+  t.Helper()
+  f, err := os.Open(file)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer f.Close()
+
+  finite := func(s string) bool {
+    return !strings.Contains(s, "NaN") && !strings.Contains(s, "Inf")
+  }
+  d := xml.NewDecoder(f)
+  for {
+    token, err := d.Token()
+    if errors.Is(err, io.EOF) {
+      return
+    }
+    if err != nil {
+      t.Errorf("%s: %v", name, err)
+      return
+    }
+    switch token := token.(type) {
+    case xml.StartElement:
+      for _, a := range token.Attr {
+        if !finite(a.Value) {
+          t.Errorf("%s: <%s %s=%q>", name, token.Name.Local, a.Name.Local, a.Value)
+        }
+      }
+    case xml.CharData:
+      if !finite(string(token)) {
+        t.Errorf("%s: text %q", name, token)
+      }
+    }
+  }
+}
+
+func TestSVG(t *testing.T) {
+  dir := t.TempDir()
+  samples := []struct {
+    name string
+    xs   []float64
+  }{
+    {"sample", []float64{-40, 1, 2, 3, 4, 5, 6, 7, 8, 9, 30}},
+    {"constant", []float64{3, 3, 3}},
+    {"extreme", []float64{-1e308, 1e308, 0}},
+    {"precision", []float64{100000000000000000, 100000000000000016, 100000000000000032}},
+  }
+  for _, tt := range samples {
+    var s svg
+    sample := stats.NewSample(tt.xs)
+    bins, err := histogram(sample)
+    if err != nil {
+      t.Fatalf("%s: histogram: %v", tt.name, err)
+    }
+    s.histogram(bins)
+    s.histogram(sample.Histogram(1))
+    s.boxPlot(sample)
+
+    file := filepath.Join(dir, tt.name+".svg")
+    if err := s.write(file); err != nil {
+      t.Fatal(err)
+    }
+    checkSVG(t, tt.name, file)
+  }
+
+  pairs := []struct {
+    name   string
+    xs, ys []float64
+  }{
+    {"scatter", []float64{1, 2, 3}, []float64{2, 4, 5}},
+    {"vertical", []float64{1, 1, 1}, []float64{2, 3, 4}},
+    {"horizontal", []float64{1, 2, 3}, []float64{2, 2, 2}},
+  }
+  for _, tt := range pairs {
+    var s svg
+    s.scatter(tt.xs, tt.ys, stats.LinearRegression(tt.xs, tt.ys))
+
+    file := filepath.Join(dir, tt.name+".svg")
+    if err := s.write(file); err != nil {
+      t.Fatal(err)
+    }
+    checkSVG(t, tt.name, file)
+  }
+}
+
+func TestTicks(t *testing.T) {
+  tests := []struct {
+    lo, hi float64
+    want   []string
+  }{
+    {0, 10, []string{"0", "2", "4", "6", "8", "10"}},
+    {0.1, 0.35, []string{"0.10", "0.15", "0.20", "0.25", "0.30", "0.35"}},
+    {3, 3, []string{"3"}},
+    {100000000000000000, 100000000000000032,
+      []string{"100000000000000000", "100000000000000016", "100000000000000032"}},
+    {-1e308, 1e308, []string{"-1e+308", "1e+308"}},
+  }
+
+  for _, tt := range tests {
+    var got []string
+    for _, tick := range ticks(tt.lo, tt.hi) {
+      got = append(got, tick.label)
+    }
+    if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+      t.Errorf("ticks(%v, %v) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+    }
+  }
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "flag"
+  "fmt"
+  "math"
+  "os"
+  "strconv"
+  "strings"
+)
+
+// lags are the values of -lags.
+type lags []int
+
+func (l *lags) String() string {
+  s := make([]string, len(*l))
+  for i, n := range *l {
+    s[i] = strconv.Itoa(n)
+  }
+  return strings.Join(s, ",")
+}
+
+func (l *lags) Set(value string) error {
+  *l = nil
+  for _, s := range strings.Split(value, ",") {
+    n, err := strconv.Atoi(strings.TrimSpace(s))
+    if err != nil || n < 1 {
+      return errors.New("expected positive lags")
+    }
+    *l = append(*l, n)
+  }
+  return nil
+}
+
+var (
+  timeseriesFlag bool
+  lagsFlag       = lags{1}
+  maFlag         int
+  alphaFlag      float64 = 0.01
+  minSegmentFlag int     = 5
+)
+
+func init() {
+  flag.BoolVar(&timeseriesFlag, "timeseries", false,
+    "A bool. Analyze the input in its order as a time series: trend,\n"+
+      "autocorrelation and change points of the mean")
+  flag.Var(&lagsFlag, "lags",
+    "A list. Comma separated lags of the autocorrelation")
+  flag.IntVar(&maFlag, "ma", 0,
+    "An int. Print the moving average of the time series over n numbers")
+  flag.Float64Var(&alphaFlag, "alpha", 0.01,
+    "A float. Significance level of the change points")
+  flag.IntVar(&minSegmentFlag, "min-segment", 5,
+    "An int. Smallest number of numbers between change points")
+}
+
+// timeseries describes the input in its order: its linear trend,
+// autocorrelation, moving average and the points where its mean shifts.
+func timeseries(reader *stats.Reader) int {
+  nums, lines, err := reader.ReadNumbered(os.Stdin)
+  if err == nil && len(nums) < 3 {
+    err = fmt.Errorf("%d valid numbers", len(nums))
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected at least three numbers, separated by newlines\n", err)
+    return exitInvalidInput
+  }
+
+  var r report
+  r.fields = append(r.fields,
+    field{key: "count", title: "Count", value: float64(len(nums)), exact: true})
+  line, test := stats.Trend(nums), stats.TrendTest(nums)
+  r.add("trend_slope", "Trend slope", line.Slope)
+  r.add("trend_intercept", "Trend intercept", line.Intercept)
+  r.add("trend_r_squared", "Trend R²", line.RSquared)
+  r.addTest("trend_p", "Trend p-value", test.P)
+  for _, lag := range lagsFlag {
+    r.add(fmt.Sprintf("acf_%d", lag),
+      fmt.Sprintf("Autocorrelation, lag %d", lag),
+      stats.Autocorrelation(nums, lag))
+  }
+
+  points := stats.ChangePoints(nums, alphaFlag, minSegmentFlag)
+  t := table{key: "change_points", title: "Change points", columns: []column{
+    {"line", true}, {"before", false}, {"after", false}, {"p", false},
+  }}
+  for _, p := range points {
+    t.rows = append(t.rows,
+      []float64{float64(lines[p.Index]), p.Before, p.After, p.P})
+  }
+  r.tables = append(r.tables, t)
+
+  if maFlag > 0 {
+    t := table{key: "moving_average", title: "Moving average",
+      columns: []column{{"line", true}, {"value", true}, {"average", false}}}
+    for i, avg := range stats.MovingAverage(nums, maFlag) {
+      j := i + maFlag - 1
+      t.rows = append(t.rows, []float64{float64(lines[j]), nums[j], avg})
+    }
+    r.tables = append(r.tables, t)
+  }
+
+  if formatFlag == "text" {
+    r.charts = append(r.charts, sparkline(nums, points))
+  }
+  return r.print()
+}
+
+// sparkline draws the series in -width characters, each the average of
+// its numbers, and marks the change points below it.
+func sparkline(nums []float64, points []stats.ChangePoint) string {
+  levels := []rune("▁▂▃▄▅▆▇█")
+  if asciiFlag {
+    levels = []rune("_.-=+*#@")
+  }
+
+  width := min(widthFlag, len(nums))
+  column := func(i int) int { return i * width / len(nums) }
+  sums, counts := make([]float64, width), make([]int, width)
+  for i, x := range nums {
+    sums[column(i)] += x
+    counts[column(i)]++
+  }
+  lo, hi := math.Inf(1), math.Inf(-1)
+  for c := range sums {
+    sums[c] /= float64(counts[c])
+    if !math.IsInf(sums[c], 0) {
+      lo, hi = min(lo, sums[c]), max(hi, sums[c])
+    }
+  }
+
+  // The columns whose sum overflows are left blank.
+  chart, marks := []rune(strings.Repeat(" ", width)), []rune(strings.Repeat(" ", width))
+  for c, avg := range sums {
+    if math.IsInf(avg, 0) || math.IsNaN(avg) {
+      continue
+    }
+    level := 0
+    if hi > lo {
+      t := fraction(avg, lo, hi)
+      level = min(max(int(t*float64(len(levels)-1)), 0), len(levels)-1)
+    }
+    chart[c] = levels[level]
+  }
+  for _, p := range points {
+    marks[column(p.Index)] = '^'
+  }
+  return fmt.Sprintf("Series:\n%s\n%s\n", string(chart), string(marks))
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+  "strings"
+  "testing"
+)
+
+func TestSparkline(t *testing.T) {
+  tests := []struct {
+    name  string
+    nums  []float64
+    width int
+    want  string
+  }{
+    {"rising", []float64{1, 2, 3, 4}, 60, "▁▃▅█"},
+    {"constant", []float64{2, 2, 2}, 60, "▁▁▁"},
+    {"extreme", []float64{-1e308, 1e308, 0, 5}, 60, "▁█▄▄"},
+    {"overflow", []float64{1e308, 1e308, 0, 5}, 3, " ▁█"},
+  }
+
+  for _, tt := range tests {
+    saved := widthFlag
+    widthFlag = tt.width
+    got := strings.Split(sparkline(tt.nums, nil), "\n")[1]
+    widthFlag = saved
+    if got != tt.want {
+      t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+    }
+  }
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+  "anscombe/stats"
+  "errors"
+  "flag"
+  "fmt"
+  "math"
+  "os"
+)
+
+var weightsFlag string
+
+func init() {
+  flag.Func("weights",
+    "A string. Read lines of a value and its weight, which is frequency\n"+
+      "(a count) or reliability (a relative importance)\n"+
+      "(trimmed and winsorized means are not available)",
+    func(s string) error {
+      if s != "frequency" && s != "reliability" {
+        return errors.New("expected frequency or reliability")
+      }
+      weightsFlag = s
+      return nil
+    })
+}
+
+// weightedMetrics are the metrics available for weighted input.
+var weightedMetrics = map[string]func(w *stats.WeightedSample) float64{
+  "count": (*stats.WeightedSample).Size,
+  "sum":   (*stats.WeightedSample).Sum,
+  "mean":  (*stats.WeightedSample).Mean,
+  "mode":  (*stats.WeightedSample).Mode,
+  "deviation": func(w *stats.WeightedSample) float64 {
+    return math.Sqrt(w.Variance(ddofFlag))
+  },
+  "variance": func(w *stats.WeightedSample) float64 {
+    return w.Variance(ddofFlag)
+  },
+  "sem": func(w *stats.WeightedSample) float64 {
+    return w.StdErr(ddofFlag)
+  },
+  "minimum": (*stats.WeightedSample).Min,
+  "maximum": (*stats.WeightedSample).Max,
+  "range":   (*stats.WeightedSample).Range,
+  "iqr":     (*stats.WeightedSample).IQR,
+  "mad":     (*stats.WeightedSample).MAD,
+  "cv": func(w *stats.WeightedSample) float64 {
+    return math.Sqrt(w.Variance(ddofFlag)) / w.Mean()
+  },
+  "skewness": (*stats.WeightedSample).Skewness,
+  "kurtosis": (*stats.WeightedSample).Kurtosis,
+}
+
+// weighted calculates the selected metrics of pre-aggregated input,
+// values with their weights, without expanding it.
+func weighted(reader *stats.Reader) int {
+  values, weights, err := reader.ReadWeighted(os.Stdin)
+  kind := stats.FrequencyWeights
+  if weightsFlag == "reliability" {
+    kind = stats.ReliabilityWeights
+  }
+  sample := stats.NewWeightedSample(values, weights, kind)
+  if err == nil && sample.Len() == 0 {
+    err = errNoData
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Incorrect input: %v\n"+
+      "expected values and non-negative weights, not all zero,"+
+      " separated by newlines\n", err)
+    return exitInvalidInput
+  }
+
+  fractions := quantiles()
+  var r report
+  for _, m := range selected() {
+    var x float64
+    if f, ok := weightedMetrics[m.name]; ok {
+      x = f(sample)
+    } else if p, ok := fractions[m.name]; ok {
+      x = sample.Quantile(p)
+    } else {
+      continue
+    }
+    f := m.field(x)
+    if m.name == "count" && kind == stats.ReliabilityWeights {
+      f.title, f.exact = "Effective count", false
+    }
+    r.fields = append(r.fields, f)
+  }
+  return r.print()
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+  "anscombe/stats"
+  "encoding/csv"
+  "errors"
+  "flag"
+  "fmt"
+  "io"
+  "os"
+  "strconv"
+  "strings"
+  "time"
+)
+
+var (
+  // windowSize is the count of numbers in the sliding window, or
+  // windowSpan is its duration, if -window is set.
+  windowSize int
+  windowSpan time.Duration
+  everyFlag  int = 1
+  ewmaFlag   float64
+)
+
+func init() {
+  flag.Func("window",
+    "A count or a duration. Calculate the metrics over a sliding window\n"+
+      "of the latest numbers, e.g. 1000, or of lines of a timestamp and\n"+
+      "a number within a period, e.g. 5m, printing them as lines arrive",
+    func(s string) error {
+      if n, err := strconv.Atoi(s); err == nil && n > 0 {
+        windowSize = n
+        return nil
+      }
+      if d, err := time.ParseDuration(s); err == nil && d > 0 {
+        windowSpan = d
+        return nil
+      }
+      return errors.New("expected a positive count or duration")
+    })
+  flag.IntVar(&everyFlag, "every", 1,
+    "An int. Print the window metrics after every n-th line")
+  flag.Float64Var(&ewmaFlag, "ewma", 0,
+    "A float. Smoothing factor between 0 and 1 of the exponentially\n"+
+      "weighted moving average printed with the window metrics")
+}
+
+// window prints the selected metrics over the sliding window after
+// every -every lines of input.
+func window(reader *stats.Reader) int {
+  sel := selected()
+  out := newRowWriter(os.Stdout)
+  ewma := stats.EWMA{Alpha: ewmaFlag}
+
+  var w stats.Window
+  var times []time.Time
+  lines := 0
+  emit := func(t time.Time) {
+    lines++
+    if lines%everyFlag != 0 {
+      return
+    }
+
+    var fields []field
+    if windowSpan != 0 {
+      sec := float64(t.UnixNano()) / 1e9
+      fields = append(fields,
+        field{key: "time", title: "Time", value: sec, exact: true})
+    }
+    for _, m := range sel {
+      fields = append(fields, m.field(m.value(w.Sample())))
+    }
+    if ewmaFlag != 0 {
+      fields = append(fields, field{key: "ewma", title: "EWMA",
+        value: ewma.Value()})
+    }
+    out.write(fields)
+  }
+
+  var err error
+  if windowSpan != 0 {
+    err = reader.ScanTimed(os.Stdin, func(t time.Time, num float64) {
+      w.Push(num)
+      ewma.Add(num)
+      times = append(times, t)
+      for t.Sub(times[0]) > windowSpan {
+        w.Pop()
+        times = times[1:]
+      }
+      emit(t)
+    })
+  } else {
+    err = reader.ScanFloats(os.Stdin, func(num float64) {
+      w.Push(num)
+      ewma.Add(num)
+      if w.Len() > windowSize {
+        w.Pop()
+      }
+      emit(time.Time{})
+    })
+  }
+  if err == nil {
+    err = out.flush()
+  }
+
+  if err != nil {
+    fmt.Fprintln(os.Stderr, errmsg(err, reader.Range))
+    return exitInvalidInput
+  }
+  return exitOK
+}
+
+// rowWriter writes rows of fields as they are calculated: key=value
+// pairs in the text format, JSON Lines or rows of a CSV/TSV table.
+type rowWriter struct {
+  w      io.Writer
+  csv    *csv.Writer
+  header bool
+}
+
+func newRowWriter(w io.Writer) *rowWriter {
+  rw := &rowWriter{w: w}
+  if formatFlag == "csv" || formatFlag == "tsv" {
+    rw.csv = csv.NewWriter(w)
+    if formatFlag == "tsv" {
+      rw.csv.Comma = '\t'
+    }
+  }
+  return rw
+}
+
+func (rw *rowWriter) write(fields []field) {
+  if rw.csv != nil {
+    if !rw.header {
+      var header []string
+      for _, f := range fields {
+        header = append(header, f.key)
+      }
+      rw.csv.Write(header)
+      rw.header = true
+    }
+    var record []string
+    for _, f := range fields {
+      record = append(record, formatNumber(f.value, f.exact))
+    }
+    rw.csv.Write(record)
+    rw.csv.Flush()
+    return
+  }
+
+  var pairs []string
+  for _, f := range fields {
+    if formatFlag == "json" {
+      pairs = append(pairs, jsonString(f.key)+": "+jsonNumber(f.value, f.exact))
+    } else {
+      pairs = append(pairs, f.key+"="+formatNumber(f.value, f.exact))
+    }
+  }
+  if formatFlag == "json" {
+    fmt.Fprintf(rw.w, "{%s}\n", strings.Join(pairs, ", "))
+  } else {
+    fmt.Fprintln(rw.w, strings.Join(pairs, " "))
+  }
+}
+
+func (rw *rowWriter) flush() error {
+  if rw.csv != nil {
+    rw.csv.Flush()
+    return rw.csv.Error()
+  }
+  return nil
+}